@@ -1,16 +1,24 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
-	"hubpay/internal/auth"
 	"hubpay/internal/config"
 	"hubpay/internal/database"
+	"hubpay/internal/graph"
 	"hubpay/internal/handlers"
+	"hubpay/internal/integrations"
+	"hubpay/internal/ledger"
 	"hubpay/internal/middleware"
+	"hubpay/internal/rates"
+	"hubpay/internal/scheduler"
+	"hubpay/internal/services"
+	"hubpay/internal/webhooks"
 )
 
 func main() {
@@ -40,16 +48,52 @@ func main() {
 	router.Use(middleware.CORS())
 	router.Use(middleware.Sessions(cfg.SessionSecret, db))
 
+	// Initialize services
+	ledgerService := ledger.New(db)
+	ratesService := rates.New(db, rates.NewECBFetcher())
+	commissionService := services.NewCommissionService(db, ledgerService, ratesService)
+	schedulerService := scheduler.New(db, commissionService)
+	payrollService := services.NewPayrollService(db, commissionService)
+
+	billingProviders := integrations.NewRegistry()
+	billingProviders.Register(integrations.NewTabsProvider(cfg.TabsAPIKey))
+	billingProviders.Register(integrations.NewConnectWiseProvider(cfg.ConnectWiseAPIKey, cfg.ConnectWiseCompanyID))
+	reconciler := integrations.NewReconciler(db, billingProviders)
+
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(db, cfg)
 	userHandler := handlers.NewUserHandler(db)
-	contractHandler := handlers.NewContractHandler(db)
-	invoiceHandler := handlers.NewInvoiceHandler(db)
-	commissionHandler := handlers.NewCommissionHandler(db)
-	adminHandler := handlers.NewAdminHandler(db)
+	contractHandler := handlers.NewContractHandler(db, schedulerService)
+	invoiceHandler := handlers.NewInvoiceHandler(db, commissionService)
+	commissionHandler := handlers.NewCommissionHandler(db, commissionService, ledgerService)
+	webhooksHandler := webhooks.NewHandler(db, cfg, commissionService)
+	adminHandler := handlers.NewAdminHandler(db, ledgerService, commissionService, webhooksHandler)
+	scheduleHandler := handlers.NewScheduleHandler(db, schedulerService)
+	payrollHandler := handlers.NewPayrollHandler(db, payrollService)
+	integrationsHandler := handlers.NewIntegrationsHandler(db, reconciler)
+	ratesHandler := handlers.NewRatesHandler(db, ratesService)
+
+	graphServer, err := graph.NewServer(db, commissionService)
+	if err != nil {
+		log.Fatal("Failed to build GraphQL schema:", err)
+	}
+
+	// Warm the commission config cache, then keep it in sync with other
+	// replicas via Postgres NOTIFY.
+	if _, err := commissionService.ReloadConfigs(context.Background()); err != nil {
+		log.Println("Initial commission config reload failed:", err)
+	}
+	if err := commissionService.ListenForConfigChanges(context.Background(), cfg.DatabaseURL); err != nil {
+		log.Println("Failed to start commission config listener:", err)
+	}
+
+	// Run the recurring invoice scheduler and billing reconciliation
+	// workers in the background for the life of the process.
+	go schedulerService.Run(context.Background(), time.Hour)
+	go reconciler.Run(context.Background(), 15*time.Minute)
 
 	// Setup routes
-	setupRoutes(router, authHandler, userHandler, contractHandler, invoiceHandler, commissionHandler, adminHandler)
+	setupRoutes(router, cfg, authHandler, userHandler, contractHandler, invoiceHandler, commissionHandler, adminHandler, webhooksHandler, graphServer, scheduleHandler, payrollHandler, integrationsHandler, ratesHandler)
 
 	// Start server
 	port := os.Getenv("PORT")
@@ -65,24 +109,36 @@ func main() {
 
 func setupRoutes(
 	router *gin.Engine,
+	cfg *config.Config,
 	authHandler *handlers.AuthHandler,
 	userHandler *handlers.UserHandler,
 	contractHandler *handlers.ContractHandler,
 	invoiceHandler *handlers.InvoiceHandler,
 	commissionHandler *handlers.CommissionHandler,
 	adminHandler *handlers.AdminHandler,
+	webhooksHandler *webhooks.Handler,
+	graphServer *graph.Server,
+	scheduleHandler *handlers.ScheduleHandler,
+	payrollHandler *handlers.PayrollHandler,
+	integrationsHandler *handlers.IntegrationsHandler,
+	ratesHandler *handlers.RatesHandler,
 ) {
+	// Webhook endpoints authenticate via provider signature, not session/JWT.
+	router.POST("/webhooks/tabs", webhooksHandler.HandleTabs)
+	router.POST("/webhooks/sendgrid", webhooksHandler.HandleSendGrid)
+
 	api := router.Group("/api")
 
 	// Authentication routes
 	api.POST("/register", authHandler.Register)
 	api.POST("/login", authHandler.Login)
-	api.POST("/logout", authHandler.Logout)
-	api.GET("/user", authHandler.GetCurrentUser)
+	api.POST("/logout", middleware.RequireAuth(cfg), authHandler.Logout)
+	api.GET("/user", middleware.RequireAuth(cfg), authHandler.GetCurrentUser)
+	api.POST("/refresh", middleware.RequireAuth(cfg), authHandler.Refresh)
 
 	// Protected routes
 	protected := api.Group("/")
-	protected.Use(middleware.RequireAuth())
+	protected.Use(middleware.RequireAuth(cfg))
 
 	// User routes
 	protected.GET("/users", userHandler.GetUsers)
@@ -100,6 +156,9 @@ func setupRoutes(
 	// Commission routes
 	protected.GET("/commissions", commissionHandler.GetCommissions)
 	protected.PUT("/commissions/:id/status", commissionHandler.UpdateCommissionStatus)
+	protected.GET("/commissions/:id/journal", commissionHandler.GetCommissionJournal)
+	protected.POST("/commissions/:id/recompute", commissionHandler.RecomputeCommission)
+	protected.POST("/commissions/recompute", commissionHandler.RecomputeCommissions)
 
 	// Admin only routes
 	admin := protected.Group("/admin")
@@ -109,6 +168,28 @@ func setupRoutes(
 	admin.GET("/approvals", adminHandler.GetPendingApprovals)
 	admin.GET("/payouts", adminHandler.GetPayouts)
 	admin.POST("/clear-database", adminHandler.ClearDatabase)
+	admin.GET("/ledger/trial-balance", adminHandler.GetTrialBalance)
+	admin.GET("/webhooks/failed", adminHandler.GetFailedWebhooks)
+	admin.POST("/webhooks/:id/retry", adminHandler.RetryWebhook)
+
+	// Recurring invoice schedule routes
+	admin.GET("/contract-schedules/:id/upcoming", scheduleHandler.GetUpcomingInvoices)
+	admin.POST("/contract-schedules/:id/cancel", scheduleHandler.CancelSchedule)
+	admin.POST("/contract-schedules/:id/backfill", scheduleHandler.BackfillSchedule)
+
+	// Payroll batching routes
+	admin.POST("/payrolls", payrollHandler.CreatePayroll)
+	admin.POST("/payrolls/:id/approve", payrollHandler.ApprovePayroll)
+	admin.POST("/payrolls/:id/pay", payrollHandler.PayPayroll)
+	admin.GET("/payrolls/:id/export", payrollHandler.ExportPayroll)
+
+	// External billing sync routes
+	admin.POST("/invoices/:id/sync", integrationsHandler.SyncInvoice)
+	admin.GET("/integrations/:provider/status", integrationsHandler.GetProviderStatus)
+
+	// Exchange rate routes
+	admin.GET("/exchange-rates", ratesHandler.ListRates)
+	admin.POST("/exchange-rates", ratesHandler.RecordRate)
 
 	// Commission configuration routes
 	admin.GET("/commission-configs", adminHandler.GetCommissionConfigs)
@@ -116,6 +197,9 @@ func setupRoutes(
 	admin.GET("/commission-configs/:id", adminHandler.GetCommissionConfig)
 	admin.PUT("/commission-configs/:id", adminHandler.UpdateCommissionConfig)
 	admin.DELETE("/commission-configs/:id", adminHandler.DeleteCommissionConfig)
+	admin.POST("/commission-configs/:id/simulate", adminHandler.SimulateCommissionConfig)
+	admin.POST("/commission-configs/reload", adminHandler.ReloadCommissionConfigs)
+	admin.GET("/commission-configs/version", adminHandler.GetCommissionConfigVersion)
 
 	// AE commission assignment routes
 	admin.POST("/ae-commission-assignments", adminHandler.AssignCommissionConfig)
@@ -129,10 +213,15 @@ func setupRoutes(
 	// Tabs API integration
 	protected.GET("/tabs/invoices/paid", invoiceHandler.GetTabsInvoices)
 
+	// GraphQL query API, same role-based auth as REST
+	protected.POST("/graphql", graphServer.Handle)
+	protected.GET("/graphql", graphServer.Handle)
+	protected.GET("/playground", graphServer.HandlePlayground)
+
 	// Serve static files for production
 	router.Static("/assets", "./dist/public/assets")
 	router.StaticFile("/", "./dist/public/index.html")
 	router.NoRoute(func(c *gin.Context) {
 		c.File("./dist/public/index.html")
 	})
-}
\ No newline at end of file
+}
@@ -0,0 +1,97 @@
+// Package auth issues and validates the signed JWTs HubPay uses to
+// authenticate API requests, replacing the old X-User-ID header scheme.
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// AccessTokenTTL is how long an issued token remains valid before the client
+// must call POST /api/refresh to rotate it.
+const AccessTokenTTL = 24 * time.Hour
+
+// RefreshWindow is how close to expiry a token must be before ShouldRefresh
+// recommends rotating it.
+const RefreshWindow = 15 * time.Minute
+
+// ErrInvalidToken is returned when a token parses but fails validation.
+var ErrInvalidToken = errors.New("auth: invalid token")
+
+// Claims are the JWT claims HubPay embeds in every issued token.
+type Claims struct {
+	UserID uint   `json:"sub"`
+	Role   string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// GenerateToken signs a new HS256 token for the given user, returning both
+// the encoded token and the claims that were embedded (callers that need the
+// jti, e.g. to blacklist it later, can read it off the returned claims).
+func GenerateToken(secret string, userID uint, role string) (string, *Claims, error) {
+	jti, err := newJTI()
+	if err != nil {
+		return "", nil, fmt.Errorf("generate jti: %w", err)
+	}
+
+	now := time.Now()
+	claims := &Claims{
+		UserID: userID,
+		Role:   role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL)),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(secret))
+	if err != nil {
+		return "", nil, fmt.Errorf("sign token: %w", err)
+	}
+
+	return signed, claims, nil
+}
+
+// ParseToken validates the signature and expiration of tokenString and
+// returns its claims.
+func ParseToken(tokenString, secret string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// ShouldRefresh reports whether claims are close enough to expiry that the
+// client should proactively exchange the token via POST /api/refresh.
+func ShouldRefresh(claims *Claims) bool {
+	if claims.ExpiresAt == nil {
+		return false
+	}
+	return time.Until(claims.ExpiresAt.Time) < RefreshWindow
+}
+
+func newJTI() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
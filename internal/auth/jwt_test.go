@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestGenerateAndParseToken(t *testing.T) {
+	tokenString, claims, err := GenerateToken("test-secret", 42, "admin")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	parsed, err := ParseToken(tokenString, "test-secret")
+	if err != nil {
+		t.Fatalf("ParseToken() error = %v", err)
+	}
+
+	if parsed.UserID != 42 || parsed.Role != "admin" {
+		t.Fatalf("parsed claims = %+v, want UserID=42 Role=admin", parsed)
+	}
+	if parsed.ID != claims.ID {
+		t.Fatalf("parsed jti = %q, want %q", parsed.ID, claims.ID)
+	}
+}
+
+func TestParseToken_TamperedSignature(t *testing.T) {
+	tokenString, _, err := GenerateToken("test-secret", 1, "ae")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	tampered := tokenString[:len(tokenString)-4] + "AAAA"
+	if _, err := ParseToken(tampered, "test-secret"); err == nil {
+		t.Fatal("ParseToken() with tampered signature succeeded, want error")
+	}
+}
+
+func TestParseToken_WrongSecret(t *testing.T) {
+	tokenString, _, err := GenerateToken("original-secret", 1, "ae")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	if _, err := ParseToken(tokenString, "different-secret"); err == nil {
+		t.Fatal("ParseToken() with wrong secret succeeded, want error")
+	}
+}
+
+func TestParseToken_Expired(t *testing.T) {
+	claims := &Claims{
+		UserID: 1,
+		Role:   "ae",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "expired-jti",
+			IssuedAt:  jwt.NewNumericDate(time.Now().Add(-2 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-1 * time.Hour)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte("test-secret"))
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+
+	if _, err := ParseToken(signed, "test-secret"); err == nil {
+		t.Fatal("ParseToken() with expired token succeeded, want error")
+	}
+}
+
+// TestParseToken_RoleEscalation ensures a client can't hand-craft a claims
+// payload with role=admin using an unsigned/none-alg token.
+func TestParseToken_RoleEscalation(t *testing.T) {
+	claims := &Claims{
+		UserID: 1,
+		Role:   "admin",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "forged-jti",
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+	forged := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
+	signed, err := forged.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+
+	if _, err := ParseToken(signed, "test-secret"); err == nil {
+		t.Fatal("ParseToken() accepted an alg=none forged token, want error")
+	}
+}
@@ -3,22 +3,28 @@ package config
 import "os"
 
 type Config struct {
-	DatabaseURL    string
-	SessionSecret  string
-	JWTSecret      string
-	SendGridAPIKey string
-	TabsAPIKey     string
-	Port           string
+	DatabaseURL              string
+	SessionSecret            string
+	JWTSecret                string
+	SendGridAPIKey           string
+	SendGridWebhookPublicKey string
+	TabsAPIKey               string
+	ConnectWiseAPIKey        string
+	ConnectWiseCompanyID     string
+	Port                     string
 }
 
 func New() *Config {
 	return &Config{
-		DatabaseURL:    getEnv("DATABASE_URL", ""),
-		SessionSecret:  getEnv("SESSION_SECRET", "your-secret-key"),
-		JWTSecret:      getEnv("JWT_SECRET", "your-jwt-secret"),
-		SendGridAPIKey: getEnv("SENDGRID_API_KEY", ""),
-		TabsAPIKey:     getEnv("TABS_API_KEY", ""),
-		Port:           getEnv("PORT", "5000"),
+		DatabaseURL:              getEnv("DATABASE_URL", ""),
+		SessionSecret:            getEnv("SESSION_SECRET", "your-secret-key"),
+		JWTSecret:                getEnv("JWT_SECRET", "your-jwt-secret"),
+		SendGridAPIKey:           getEnv("SENDGRID_API_KEY", ""),
+		SendGridWebhookPublicKey: getEnv("SENDGRID_WEBHOOK_PUBLIC_KEY", ""),
+		TabsAPIKey:               getEnv("TABS_API_KEY", ""),
+		ConnectWiseAPIKey:        getEnv("CONNECTWISE_API_KEY", ""),
+		ConnectWiseCompanyID:     getEnv("CONNECTWISE_COMPANY_ID", ""),
+		Port:                     getEnv("PORT", "5000"),
 	}
 }
 
@@ -27,4 +33,4 @@ func getEnv(key, defaultValue string) string {
 		return value
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
@@ -1,10 +1,11 @@
 package database
 
 import (
-	"hubpay/internal/models"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"hubpay/internal/ledger"
+	"hubpay/internal/models"
 )
 
 func New(databaseURL string) (*gorm.DB, error) {
@@ -19,7 +20,7 @@ func New(databaseURL string) (*gorm.DB, error) {
 }
 
 func Migrate(db *gorm.DB) error {
-	return db.AutoMigrate(
+	if err := db.AutoMigrate(
 		&models.User{},
 		&models.Contract{},
 		&models.Invoice{},
@@ -27,5 +28,48 @@ func Migrate(db *gorm.DB) error {
 		&models.CommissionConfig{},
 		&models.AECommissionAssignment{},
 		&models.Invitation{},
-	)
-}
\ No newline at end of file
+		&models.TokenBlacklist{},
+		&models.WebhookEvent{},
+		&models.WebhookDelivery{},
+		&models.ContractSchedule{},
+		&models.Payroll{},
+		&models.PayrollCommission{},
+		&models.CommissionAudit{},
+		&models.InvoiceSync{},
+		&models.ExchangeRate{},
+		&ledger.Account{},
+		&ledger.JournalEntry{},
+	); err != nil {
+		return err
+	}
+
+	if err := enforceJournalImmutability(db); err != nil {
+		return err
+	}
+
+	if _, err := ledger.New(db).BackfillOpeningEntries(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// enforceJournalImmutability backs the GORM-level BeforeUpdate/BeforeDelete
+// hooks on ledger.JournalEntry with a DB trigger, so the append-only
+// guarantee holds even for writes that bypass the application (manual SQL,
+// other services sharing the database).
+func enforceJournalImmutability(db *gorm.DB) error {
+	return db.Exec(`
+		CREATE OR REPLACE FUNCTION reject_journal_entry_mutation()
+		RETURNS TRIGGER AS $$
+		BEGIN
+			RAISE EXCEPTION 'journal_entries is append-only';
+		END;
+		$$ LANGUAGE plpgsql;
+
+		DROP TRIGGER IF EXISTS journal_entries_immutable ON journal_entries;
+		CREATE TRIGGER journal_entries_immutable
+			BEFORE UPDATE OR DELETE ON journal_entries
+			FOR EACH ROW EXECUTE FUNCTION reject_journal_entry_mutation();
+	`).Error
+}
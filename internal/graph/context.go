@@ -0,0 +1,45 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/graphql-go/graphql"
+)
+
+// authKey values are stashed on the request context before it reaches
+// graphql-go, mirroring what middleware.RequireAuth sets on the gin
+// context, so resolvers can enforce the same role checks as REST.
+type authKey string
+
+const (
+	userIDKey authKey = "userID"
+	roleKey   authKey = "role"
+)
+
+// WithAuth attaches the authenticated caller's identity to ctx for
+// resolvers to read via callerRole/callerUserID.
+func WithAuth(ctx context.Context, userID uint, role string) context.Context {
+	ctx = context.WithValue(ctx, userIDKey, userID)
+	ctx = context.WithValue(ctx, roleKey, role)
+	return ctx
+}
+
+func callerRole(ctx context.Context) string {
+	role, _ := ctx.Value(roleKey).(string)
+	return role
+}
+
+func callerUserID(ctx context.Context) uint {
+	userID, _ := ctx.Value(userIDKey).(uint)
+	return userID
+}
+
+// requireAdmin fails the resolver unless the caller authenticated as admin,
+// the same check middleware.RequireAdmin applies to the equivalent REST
+// route.
+func requireAdmin(p graphql.ResolveParams) error {
+	if callerRole(p.Context) != "admin" {
+		return errAdminRequired
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"github.com/gin-gonic/gin"
+	graphqlhandler "github.com/graphql-go/handler"
+	"gorm.io/gorm"
+
+	"hubpay/internal/services"
+)
+
+// Server wraps a built schema so /graphql and /playground can share it
+// instead of each parsing the schema independently.
+type Server struct {
+	api        *graphqlhandler.Handler
+	playground *graphqlhandler.Handler
+}
+
+// NewServer builds the GraphQL schema backing both the API and playground
+// endpoints.
+func NewServer(db *gorm.DB, commissionService *services.CommissionService) (*Server, error) {
+	schema, err := NewSchema(db, commissionService)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		api: graphqlhandler.New(&graphqlhandler.Config{
+			Schema:   &schema,
+			Pretty:   true,
+			GraphiQL: false,
+		}),
+		playground: graphqlhandler.New(&graphqlhandler.Config{
+			Schema:   &schema,
+			GraphiQL: true,
+		}),
+	}, nil
+}
+
+// Handle serves GET and POST /graphql. It runs behind the caller's normal
+// RequireAuth middleware; WithAuth stashes the authenticated userID/role on
+// the request context so resolvers can enforce the same role checks as
+// REST.
+func (s *Server) Handle(c *gin.Context) {
+	ctx := WithAuth(c.Request.Context(), c.GetUint("userID"), c.GetString("role"))
+	s.api.ContextHandler(ctx, c.Writer, c.Request)
+}
+
+// HandlePlayground serves a GraphiQL UI pointed at /graphql, gated behind
+// the same auth as the API itself so the schema isn't browsable
+// anonymously.
+func (s *Server) HandlePlayground(c *gin.Context) {
+	ctx := WithAuth(c.Request.Context(), c.GetUint("userID"), c.GetString("role"))
+	s.playground.ContextHandler(ctx, c.Writer, c.Request)
+}
@@ -0,0 +1,385 @@
+package graph
+
+import (
+	"errors"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"gorm.io/gorm"
+
+	"hubpay/internal/models"
+	"hubpay/internal/services"
+)
+
+var errAdminRequired = errors.New("graph: admin role required")
+
+// resolvers closes over the dependencies query/mutation fields need, the
+// same db+service pair the REST handlers in this chunk are constructed
+// with.
+type resolvers struct {
+	db         *gorm.DB
+	commission *services.CommissionService
+}
+
+// NewSchema builds the GraphQL schema backing /graphql. Every field enforces
+// the same admin/ae role split as its REST equivalent via requireAdmin.
+func NewSchema(db *gorm.DB, commissionService *services.CommissionService) (graphql.Schema, error) {
+	r := &resolvers{db: db, commission: commissionService}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"users": &graphql.Field{
+				Type: graphql.NewList(userType),
+				Args: graphql.FieldConfigArgument{
+					"role": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: r.resolveUsers,
+			},
+			"contracts": &graphql.Field{
+				Type: graphql.NewList(contractType),
+				Args: graphql.FieldConfigArgument{
+					"aeId":         &graphql.ArgumentConfig{Type: graphql.Int},
+					"contractType": &graphql.ArgumentConfig{Type: graphql.String},
+					"isPilot":      &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"limit":        &graphql.ArgumentConfig{Type: graphql.Int},
+					"offset":       &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: r.resolveContracts,
+			},
+			"invoices": &graphql.Field{
+				Type: graphql.NewList(invoiceType),
+				Args: graphql.FieldConfigArgument{
+					"contractId":  &graphql.ArgumentConfig{Type: graphql.Int},
+					"revenueType": &graphql.ArgumentConfig{Type: graphql.String},
+					"startDate":   &graphql.ArgumentConfig{Type: graphql.String},
+					"endDate":     &graphql.ArgumentConfig{Type: graphql.String},
+					"limit":       &graphql.ArgumentConfig{Type: graphql.Int},
+					"offset":      &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: r.resolveInvoices,
+			},
+			"commissions": &graphql.Field{
+				Type: graphql.NewList(commissionType),
+				Args: graphql.FieldConfigArgument{
+					"aeId":      &graphql.ArgumentConfig{Type: graphql.Int},
+					"status":    &graphql.ArgumentConfig{Type: graphql.String},
+					"startDate": &graphql.ArgumentConfig{Type: graphql.String},
+					"endDate":   &graphql.ArgumentConfig{Type: graphql.String},
+					"sortBy":    &graphql.ArgumentConfig{Type: graphql.String},
+					"sortDir":   &graphql.ArgumentConfig{Type: graphql.String},
+					"limit":     &graphql.ArgumentConfig{Type: graphql.Int},
+					"offset":    &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: r.resolveCommissions,
+			},
+			"commissionConfigs": &graphql.Field{
+				Type: graphql.NewList(commissionConfigType),
+				Args: graphql.FieldConfigArgument{
+					"isActive": &graphql.ArgumentConfig{Type: graphql.Boolean},
+				},
+				Resolve: r.resolveCommissionConfigs,
+			},
+			"aeCommissionAssignments": &graphql.Field{
+				Type: graphql.NewList(assignmentType),
+				Args: graphql.FieldConfigArgument{
+					"aeId": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: r.resolveAssignments,
+			},
+		},
+	})
+
+	contractInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "ContractInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"clientName":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"aeId":           &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"contractValue":  &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"acv":            &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"contractType":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"contractLength": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"paymentTerms":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"isPilot":        &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		},
+	})
+
+	invoiceInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "InvoiceInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"contractId":  &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"amount":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"invoiceDate": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"revenueType": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createContract": &graphql.Field{
+				Type: contractType,
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(contractInput)},
+				},
+				Resolve: r.resolveCreateContract,
+			},
+			"createInvoice": &graphql.Field{
+				Type: invoiceType,
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(invoiceInput)},
+				},
+				Resolve: r.resolveCreateInvoice,
+			},
+			"updateCommissionStatus": &graphql.Field{
+				Type: commissionType,
+				Args: graphql.FieldConfigArgument{
+					"id":              &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"status":          &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"rejectionReason": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: r.resolveUpdateCommissionStatus,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+func (r *resolvers) resolveUsers(p graphql.ResolveParams) (interface{}, error) {
+	query := r.db
+	if role, ok := p.Args["role"].(string); ok && role != "" {
+		query = query.Where("role = ?", role)
+	}
+
+	var users []models.User
+	if err := query.Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *resolvers) resolveContracts(p graphql.ResolveParams) (interface{}, error) {
+	query := r.db.Preload("AE")
+	if aeID, ok := p.Args["aeId"].(int); ok {
+		query = query.Where("ae_id = ?", aeID)
+	}
+	if contractType, ok := p.Args["contractType"].(string); ok && contractType != "" {
+		query = query.Where("contract_type = ?", contractType)
+	}
+	if isPilot, ok := p.Args["isPilot"].(bool); ok {
+		query = query.Where("is_pilot = ?", isPilot)
+	}
+	query = paginate(query, p)
+
+	var contracts []models.Contract
+	if err := query.Find(&contracts).Error; err != nil {
+		return nil, err
+	}
+	return contracts, nil
+}
+
+func (r *resolvers) resolveInvoices(p graphql.ResolveParams) (interface{}, error) {
+	query := r.db.Preload("Contract").Preload("Contract.AE")
+	if contractID, ok := p.Args["contractId"].(int); ok {
+		query = query.Where("contract_id = ?", contractID)
+	}
+	if revenueType, ok := p.Args["revenueType"].(string); ok && revenueType != "" {
+		query = query.Where("revenue_type = ?", revenueType)
+	}
+	var err error
+	if query, err = dateRange(query, p, "invoice_date"); err != nil {
+		return nil, err
+	}
+	query = paginate(query, p)
+
+	var invoices []models.Invoice
+	if err := query.Find(&invoices).Error; err != nil {
+		return nil, err
+	}
+	return invoices, nil
+}
+
+func (r *resolvers) resolveCommissions(p graphql.ResolveParams) (interface{}, error) {
+	query := r.db.Preload("AE").Preload("Invoice").Preload("Invoice.Contract")
+	if aeID, ok := p.Args["aeId"].(int); ok {
+		query = query.Where("ae_id = ?", aeID)
+	}
+	if status, ok := p.Args["status"].(string); ok && status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var err error
+	if query, err = dateRange(query, p, "created_at"); err != nil {
+		return nil, err
+	}
+	query = sortBy(query, p, "created_at")
+	query = paginate(query, p)
+
+	var commissions []models.Commission
+	if err := query.Find(&commissions).Error; err != nil {
+		return nil, err
+	}
+	return commissions, nil
+}
+
+func (r *resolvers) resolveCommissionConfigs(p graphql.ResolveParams) (interface{}, error) {
+	if err := requireAdmin(p); err != nil {
+		return nil, err
+	}
+
+	query := r.db
+	if isActive, ok := p.Args["isActive"].(bool); ok {
+		query = query.Where("is_active = ?", isActive)
+	}
+
+	var configs []models.CommissionConfig
+	if err := query.Find(&configs).Error; err != nil {
+		return nil, err
+	}
+	return configs, nil
+}
+
+func (r *resolvers) resolveAssignments(p graphql.ResolveParams) (interface{}, error) {
+	if err := requireAdmin(p); err != nil {
+		return nil, err
+	}
+
+	query := r.db.Preload("AE").Preload("CommissionConfig")
+	if aeID, ok := p.Args["aeId"].(int); ok {
+		query = query.Where("ae_id = ?", aeID)
+	}
+
+	var assignments []models.AECommissionAssignment
+	if err := query.Order("start_date DESC").Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+func (r *resolvers) resolveCreateContract(p graphql.ResolveParams) (interface{}, error) {
+	input := p.Args["input"].(map[string]interface{})
+
+	contract := models.Contract{
+		ClientName:     input["clientName"].(string),
+		AEID:           uint(input["aeId"].(int)),
+		ContractValue:  int64(input["contractValue"].(int)),
+		ACV:            int64(input["acv"].(int)),
+		ContractType:   input["contractType"].(string),
+		ContractLength: input["contractLength"].(int),
+		PaymentTerms:   input["paymentTerms"].(string),
+		CreatedBy:      callerUserID(p.Context),
+	}
+	if isPilot, ok := input["isPilot"].(bool); ok {
+		contract.IsPilot = isPilot
+	}
+
+	if err := r.db.Create(&contract).Error; err != nil {
+		return nil, err
+	}
+
+	r.db.Preload("AE").First(&contract, contract.ID)
+	return contract, nil
+}
+
+func (r *resolvers) resolveCreateInvoice(p graphql.ResolveParams) (interface{}, error) {
+	input := p.Args["input"].(map[string]interface{})
+
+	invoiceDate, err := time.Parse("2006-01-02", input["invoiceDate"].(string))
+	if err != nil {
+		return nil, errors.New("invoiceDate must be YYYY-MM-DD")
+	}
+
+	invoice := models.Invoice{
+		ContractID:  uint(input["contractId"].(int)),
+		Amount:      int64(input["amount"].(int)),
+		InvoiceDate: invoiceDate,
+		RevenueType: input["revenueType"].(string),
+		CreatedBy:   callerUserID(p.Context),
+	}
+
+	if err := r.db.Create(&invoice).Error; err != nil {
+		return nil, err
+	}
+
+	if _, err := r.commission.CalculateCommission(invoice); err != nil {
+		return nil, err
+	}
+
+	r.db.Preload("Contract").First(&invoice, invoice.ID)
+	return invoice, nil
+}
+
+func (r *resolvers) resolveUpdateCommissionStatus(p graphql.ResolveParams) (interface{}, error) {
+	id := uint(p.Args["id"].(int))
+	status := p.Args["status"].(string)
+	rejectionReason, _ := p.Args["rejectionReason"].(string)
+
+	return r.commission.UpdateStatus(id, status, callerUserID(p.Context), rejectionReason)
+}
+
+// dateRange scopes query to [startDate, endDate] on column if either arg is
+// present, parsing both as YYYY-MM-DD.
+func dateRange(query *gorm.DB, p graphql.ResolveParams, column string) (*gorm.DB, error) {
+	if startDate, ok := p.Args["startDate"].(string); ok && startDate != "" {
+		parsed, err := time.Parse("2006-01-02", startDate)
+		if err != nil {
+			return nil, errors.New("startDate must be YYYY-MM-DD")
+		}
+		query = query.Where(column+" >= ?", parsed)
+	}
+	if endDate, ok := p.Args["endDate"].(string); ok && endDate != "" {
+		parsed, err := time.Parse("2006-01-02", endDate)
+		if err != nil {
+			return nil, errors.New("endDate must be YYYY-MM-DD")
+		}
+		query = query.Where(column+" <= ?", parsed)
+	}
+	return query, nil
+}
+
+// commissionSortColumns allowlists the columns sortBy may order by, since
+// the column name is interpolated into the query rather than bound as a
+// parameter.
+var commissionSortColumns = map[string]bool{
+	"created_at":       true,
+	"total_commission": true,
+	"status":           true,
+	"ae_id":            true,
+}
+
+// sortBy orders query by the requested column (defaulting to defaultColumn,
+// restricted to commissionSortColumns) and direction (defaulting to
+// descending).
+func sortBy(query *gorm.DB, p graphql.ResolveParams, defaultColumn string) *gorm.DB {
+	column := defaultColumn
+	if field, ok := p.Args["sortBy"].(string); ok && commissionSortColumns[field] {
+		column = field
+	}
+
+	direction := "DESC"
+	if dir, ok := p.Args["sortDir"].(string); ok && dir == "ASC" {
+		direction = "ASC"
+	}
+
+	return query.Order(column + " " + direction)
+}
+
+// paginate applies limit/offset, capping limit at 200 and defaulting to 50
+// so an unbounded query can't be used to dump the whole table in one round
+// trip.
+func paginate(query *gorm.DB, p graphql.ResolveParams) *gorm.DB {
+	limit := 50
+	if l, ok := p.Args["limit"].(int); ok && l > 0 {
+		limit = l
+	}
+	if limit > 200 {
+		limit = 200
+	}
+
+	offset := 0
+	if o, ok := p.Args["offset"].(int); ok && o > 0 {
+		offset = o
+	}
+
+	return query.Limit(limit).Offset(offset)
+}
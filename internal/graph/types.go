@@ -0,0 +1,96 @@
+// Package graph exposes User, Contract, Invoice, Commission,
+// CommissionConfig, and AECommissionAssignment over GraphQL so BI tools and
+// spreadsheets can pull nested commission -> invoice -> contract -> AE data
+// in one round-trip instead of stitching together several REST calls.
+package graph
+
+import (
+	"github.com/graphql-go/graphql"
+)
+
+var userType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "User",
+	Fields: graphql.Fields{
+		"id":    &graphql.Field{Type: graphql.Int},
+		"email": &graphql.Field{Type: graphql.String},
+		"name":  &graphql.Field{Type: graphql.String},
+		"role":  &graphql.Field{Type: graphql.String},
+	},
+})
+
+var contractType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Contract",
+	Fields: graphql.Fields{
+		"id":             &graphql.Field{Type: graphql.Int},
+		"clientName":     &graphql.Field{Type: graphql.String},
+		"aeId":           &graphql.Field{Type: graphql.Int},
+		"ae":             &graphql.Field{Type: userType},
+		"contractValue":  &graphql.Field{Type: graphql.Int},
+		"acv":            &graphql.Field{Type: graphql.Int},
+		"contractType":   &graphql.Field{Type: graphql.String},
+		"contractLength": &graphql.Field{Type: graphql.Int},
+		"paymentTerms":   &graphql.Field{Type: graphql.String},
+		"isPilot":        &graphql.Field{Type: graphql.Boolean},
+	},
+})
+
+var invoiceType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Invoice",
+	Fields: graphql.Fields{
+		"id":            &graphql.Field{Type: graphql.Int},
+		"contractId":    &graphql.Field{Type: graphql.Int},
+		"contract":      &graphql.Field{Type: contractType},
+		"amount":        &graphql.Field{Type: graphql.Int},
+		"invoiceDate":   &graphql.Field{Type: graphql.DateTime},
+		"revenueType":   &graphql.Field{Type: graphql.String},
+		"tabsInvoiceId": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var commissionConfigType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "CommissionConfig",
+	Fields: graphql.Fields{
+		"id":                 &graphql.Field{Type: graphql.Int},
+		"name":               &graphql.Field{Type: graphql.String},
+		"baseRate":           &graphql.Field{Type: graphql.Float},
+		"pilotBonusRate":     &graphql.Field{Type: graphql.Float},
+		"multiYearBonusRate": &graphql.Field{Type: graphql.Float},
+		"upfrontBonusRate":   &graphql.Field{Type: graphql.Float},
+		"oteCap":             &graphql.Field{Type: graphql.Int},
+		"deceleratorRate":    &graphql.Field{Type: graphql.Float},
+		"isActive":           &graphql.Field{Type: graphql.Boolean},
+		"version":            &graphql.Field{Type: graphql.Int},
+	},
+})
+
+var commissionType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Commission",
+	Fields: graphql.Fields{
+		"id":              &graphql.Field{Type: graphql.Int},
+		"invoiceId":       &graphql.Field{Type: graphql.Int},
+		"invoice":         &graphql.Field{Type: invoiceType},
+		"aeId":            &graphql.Field{Type: graphql.Int},
+		"ae":              &graphql.Field{Type: userType},
+		"configVersion":   &graphql.Field{Type: graphql.Int},
+		"baseCommission":  &graphql.Field{Type: graphql.Int},
+		"pilotBonus":      &graphql.Field{Type: graphql.Int},
+		"multiYearBonus":  &graphql.Field{Type: graphql.Int},
+		"upfrontBonus":    &graphql.Field{Type: graphql.Int},
+		"totalCommission": &graphql.Field{Type: graphql.Int},
+		"status":          &graphql.Field{Type: graphql.String},
+		"rejectionReason": &graphql.Field{Type: graphql.String},
+		"oteCapApplied":   &graphql.Field{Type: graphql.Boolean},
+	},
+})
+
+var assignmentType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "AECommissionAssignment",
+	Fields: graphql.Fields{
+		"id":               &graphql.Field{Type: graphql.Int},
+		"aeId":             &graphql.Field{Type: graphql.Int},
+		"ae":               &graphql.Field{Type: userType},
+		"commissionConfig": &graphql.Field{Type: commissionConfigType},
+		"startDate":        &graphql.Field{Type: graphql.DateTime},
+		"endDate":          &graphql.Field{Type: graphql.DateTime},
+	},
+})
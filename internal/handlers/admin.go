@@ -0,0 +1,481 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"hubpay/internal/ledger"
+	"hubpay/internal/models"
+	"hubpay/internal/services"
+	"hubpay/internal/webhooks"
+)
+
+type AdminHandler struct {
+	db         *gorm.DB
+	ledger     *ledger.Ledger
+	commission *services.CommissionService
+	webhooks   *webhooks.Handler
+}
+
+func NewAdminHandler(db *gorm.DB, lg *ledger.Ledger, commissionService *services.CommissionService, webhooksHandler *webhooks.Handler) *AdminHandler {
+	return &AdminHandler{db: db, ledger: lg, commission: commissionService, webhooks: webhooksHandler}
+}
+
+func (h *AdminHandler) GetCommissionConfigs(c *gin.Context) {
+	var configs []models.CommissionConfig
+	if err := h.db.Find(&configs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch commission configs"})
+		return
+	}
+
+	c.JSON(http.StatusOK, configs)
+}
+
+func (h *AdminHandler) GetCommissionConfig(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid commission config ID"})
+		return
+	}
+
+	var config models.CommissionConfig
+	if err := h.db.First(&config, uint(id)).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Commission config not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, config)
+}
+
+func (h *AdminHandler) CreateCommissionConfig(c *gin.Context) {
+	var req models.CreateCommissionConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	config := models.CommissionConfig{
+		Name:               req.Name,
+		Description:        req.Description,
+		BaseRate:           req.BaseRate,
+		PilotBonusRate:     req.PilotBonusRate,
+		MultiYearBonusRate: req.MultiYearBonusRate,
+		UpfrontBonusRate:   req.UpfrontBonusRate,
+		OTECap:             req.OTECap,
+		DeceleratorRate:    req.DeceleratorRate,
+		IsActive:           req.IsActive,
+		CreatedBy:          c.GetUint("userID"),
+	}
+
+	if err := h.db.Create(&config).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create commission config"})
+		return
+	}
+
+	h.afterConfigMutation(c)
+	c.JSON(http.StatusCreated, config)
+}
+
+// UpdateCommissionConfig edits a config in place only if it has never been
+// assigned to an AE or used to calculate a commission. Once referenced, an
+// "edit" instead supersedes it: a new row is created with Version bumped
+// and the old row's SupersededByID is set, so every commission already
+// calculated under it keeps pointing at the exact values that produced it.
+func (h *AdminHandler) UpdateCommissionConfig(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid commission config ID"})
+		return
+	}
+
+	var config models.CommissionConfig
+	if err := h.db.First(&config, uint(id)).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Commission config not found"})
+		return
+	}
+
+	var req models.CreateCommissionConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	referenced, err := h.configIsReferenced(config.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check commission config usage"})
+		return
+	}
+
+	if !referenced {
+		config.Name = req.Name
+		config.Description = req.Description
+		config.BaseRate = req.BaseRate
+		config.PilotBonusRate = req.PilotBonusRate
+		config.MultiYearBonusRate = req.MultiYearBonusRate
+		config.UpfrontBonusRate = req.UpfrontBonusRate
+		config.OTECap = req.OTECap
+		config.DeceleratorRate = req.DeceleratorRate
+		config.IsActive = req.IsActive
+
+		if err := h.db.Save(&config).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update commission config"})
+			return
+		}
+
+		h.afterConfigMutation(c)
+		c.JSON(http.StatusOK, config)
+		return
+	}
+
+	next := models.CommissionConfig{
+		Name:               req.Name,
+		Description:        req.Description,
+		BaseRate:           req.BaseRate,
+		PilotBonusRate:     req.PilotBonusRate,
+		MultiYearBonusRate: req.MultiYearBonusRate,
+		UpfrontBonusRate:   req.UpfrontBonusRate,
+		OTECap:             req.OTECap,
+		DeceleratorRate:    req.DeceleratorRate,
+		IsActive:           req.IsActive,
+		Version:            config.Version + 1,
+		CreatedBy:          c.GetUint("userID"),
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&next).Error; err != nil {
+			return err
+		}
+		return tx.Model(&config).Update("superseded_by_id", next.ID).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to supersede commission config"})
+		return
+	}
+
+	h.afterConfigMutation(c)
+	c.JSON(http.StatusOK, next)
+}
+
+func (h *AdminHandler) DeleteCommissionConfig(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid commission config ID"})
+		return
+	}
+
+	referenced, err := h.configIsReferenced(uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check commission config usage"})
+		return
+	}
+	if referenced {
+		c.JSON(http.StatusConflict, gin.H{"error": "Commission config has been assigned or used and cannot be deleted"})
+		return
+	}
+
+	if err := h.db.Delete(&models.CommissionConfig{}, uint(id)).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete commission config"})
+		return
+	}
+
+	h.afterConfigMutation(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Commission config deleted successfully"})
+}
+
+// configIsReferenced reports whether a commission config has already
+// produced a commission or been assigned to an AE, the point past which it
+// must be superseded rather than edited or deleted.
+func (h *AdminHandler) configIsReferenced(configID uint) (bool, error) {
+	var count int64
+	if err := h.db.Model(&models.Commission{}).Where("commission_config_id = ?", configID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	if err := h.db.Model(&models.AECommissionAssignment{}).Where("commission_config_id = ?", configID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// SimulateCommissionConfig previews the effect of applying config to
+// commissions already booked in a date range, without writing anything.
+func (h *AdminHandler) SimulateCommissionConfig(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid commission config ID"})
+		return
+	}
+
+	var req models.SimulateCommissionConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	start, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "startDate must be YYYY-MM-DD"})
+		return
+	}
+	end, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "endDate must be YYYY-MM-DD"})
+		return
+	}
+
+	config, err := h.commission.ConfigByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Commission config not found"})
+		return
+	}
+
+	result, err := h.commission.SimulateConfig(req.AEIDs, start, end, config)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to simulate commission config"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *AdminHandler) AssignCommissionConfig(c *gin.Context) {
+	var req models.AssignCommissionConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "startDate must be YYYY-MM-DD"})
+		return
+	}
+
+	assignment := models.AECommissionAssignment{
+		AEID:               req.AEID,
+		CommissionConfigID: req.CommissionConfigID,
+		StartDate:          startDate,
+		CreatedBy:          c.GetUint("userID"),
+	}
+
+	if req.EndDate != "" {
+		endDate, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "endDate must be YYYY-MM-DD"})
+			return
+		}
+		assignment.EndDate = &endDate
+	}
+
+	if err := h.db.Create(&assignment).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign commission config"})
+		return
+	}
+
+	h.db.Preload("CommissionConfig").Preload("AE").First(&assignment, assignment.ID)
+
+	h.afterConfigMutation(c)
+	c.JSON(http.StatusCreated, assignment)
+}
+
+func (h *AdminHandler) GetCommissionAssignmentsForAE(c *gin.Context) {
+	aeID, err := strconv.ParseUint(c.Param("aeId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid AE ID"})
+		return
+	}
+
+	var assignments []models.AECommissionAssignment
+	if err := h.db.Preload("CommissionConfig").Where("ae_id = ?", aeID).Order("start_date DESC").Find(&assignments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch commission assignments"})
+		return
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
+
+func (h *AdminHandler) GetAllCommissionAssignments(c *gin.Context) {
+	var assignments []models.AECommissionAssignment
+	if err := h.db.Preload("CommissionConfig").Preload("AE").Order("start_date DESC").Find(&assignments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch commission assignments"})
+		return
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
+
+// ReloadCommissionConfigs forces an immediate cache refresh, for admins who
+// want to confirm a config change has taken effect without waiting on the
+// NOTIFY fan-out.
+func (h *AdminHandler) ReloadCommissionConfigs(c *gin.Context) {
+	diff, err := h.commission.ReloadConfigs(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reload commission configs"})
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// GetCommissionConfigVersion reports the cache generation so the admin UI
+// can show staleness relative to the last mutation.
+func (h *AdminHandler) GetCommissionConfigVersion(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"version": h.commission.Version()})
+}
+
+// afterConfigMutation reloads this replica's cache immediately and notifies
+// every other replica to do the same.
+func (h *AdminHandler) afterConfigMutation(c *gin.Context) {
+	if _, err := h.commission.ReloadConfigs(c.Request.Context()); err != nil {
+		c.Error(err)
+	}
+	if err := h.commission.NotifyConfigChanged(); err != nil {
+		c.Error(err)
+	}
+}
+
+// GetTrialBalance proves the ledger books balance for one AE as of a point
+// in time (defaulting to now), one line per account type.
+func (h *AdminHandler) GetTrialBalance(c *gin.Context) {
+	aeIDParam := c.Query("ae_id")
+	aeID, err := strconv.ParseUint(aeIDParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ae_id is required"})
+		return
+	}
+
+	asOf := time.Now()
+	if asOfParam := c.Query("as_of"); asOfParam != "" {
+		parsed, err := time.Parse("2006-01-02", asOfParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "as_of must be YYYY-MM-DD"})
+			return
+		}
+		asOf = parsed
+	}
+
+	balances, err := h.ledger.TrialBalance(uint(aeID), asOf)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute trial balance"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"aeId": aeID, "asOf": asOf, "balances": balances})
+}
+
+// GetFailedWebhooks lists deliveries still awaiting a successful retry.
+func (h *AdminHandler) GetFailedWebhooks(c *gin.Context) {
+	deliveries, err := h.webhooks.ListFailed()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch failed webhook deliveries"})
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// RetryWebhook re-dispatches one failed delivery on demand, instead of
+// waiting for its next scheduled backoff.
+func (h *AdminHandler) RetryWebhook(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID"})
+		return
+	}
+
+	if err := h.webhooks.Retry(uint(id)); err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook delivery retried successfully"})
+}
+
+// GetDashboard summarizes commission/payroll volume for the admin landing
+// page: how much is sitting in each commission status, and how much payroll
+// has actually gone out the door.
+func (h *AdminHandler) GetDashboard(c *gin.Context) {
+	var pendingCount, approvedCount, paidCount int64
+	if err := h.db.Model(&models.Commission{}).Where("status = ?", "pending").Count(&pendingCount).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch dashboard"})
+		return
+	}
+	if err := h.db.Model(&models.Commission{}).Where("status = ?", "approved").Count(&approvedCount).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch dashboard"})
+		return
+	}
+	if err := h.db.Model(&models.Commission{}).Where("status = ?", "paid").Count(&paidCount).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch dashboard"})
+		return
+	}
+
+	var totalPaidOut int64
+	if err := h.db.Model(&models.Payroll{}).Where("status = ?", "paid").
+		Select("COALESCE(SUM(total_amount), 0)").Scan(&totalPaidOut).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch dashboard"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pendingCommissions":  pendingCount,
+		"approvedCommissions": approvedCount,
+		"paidCommissions":     paidCount,
+		"totalPaidOut":        totalPaidOut,
+	})
+}
+
+// GetPendingApprovals lists commissions awaiting an admin's approve/reject
+// decision.
+func (h *AdminHandler) GetPendingApprovals(c *gin.Context) {
+	var commissions []models.Commission
+	if err := h.db.Preload("AE").Preload("Invoice").Where("status = ?", "pending").Find(&commissions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch pending approvals"})
+		return
+	}
+
+	c.JSON(http.StatusOK, commissions)
+}
+
+// GetPayouts lists payroll batches, most recent first.
+func (h *AdminHandler) GetPayouts(c *gin.Context) {
+	var payrolls []models.Payroll
+	if err := h.db.Order("created_at DESC").Find(&payrolls).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch payouts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, payrolls)
+}
+
+// ClearDatabase wipes non-ledger application data for use against disposable
+// dev/staging databases. It deliberately leaves journal_entries and accounts
+// untouched: the ledger is append-only by design (see
+// enforceJournalImmutability), so clearing it out from under the app would
+// just trip the DB trigger that protects it.
+func (h *AdminHandler) ClearDatabase(c *gin.Context) {
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Exec(`TRUNCATE TABLE
+			payroll_commissions, payrolls,
+			commission_audits, commissions,
+			invoice_syncs, invoices,
+			contract_schedules, contracts,
+			ae_commission_assignments, commission_configs,
+			exchange_rates,
+			webhook_deliveries, webhook_events,
+			invitations, token_blacklists
+			RESTART IDENTITY CASCADE`).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clear database"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Database cleared successfully"})
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"hubpay/internal/auth"
+	"hubpay/internal/config"
+	"hubpay/internal/models"
+)
+
+const tokenCookieName = "hubpay_token"
+
+type AuthHandler struct {
+	db  *gorm.DB
+	cfg *config.Config
+}
+
+func NewAuthHandler(db *gorm.DB, cfg *config.Config) *AuthHandler {
+	return &AuthHandler{db: db, cfg: cfg}
+}
+
+func (h *AuthHandler) Register(c *gin.Context) {
+	var req models.RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+		return
+	}
+
+	user := models.User{
+		Email:    req.Email,
+		Name:     req.Name,
+		Password: string(hashed),
+		Role:     req.Role,
+	}
+
+	if err := h.db.Create(&user).Error; err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Email already registered"})
+		return
+	}
+
+	h.issueToken(c, http.StatusCreated, user)
+}
+
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req models.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var user models.User
+	if err := h.db.Where("email = ?", req.Email).First(&user).Error; err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		return
+	}
+
+	h.issueToken(c, http.StatusOK, user)
+}
+
+// Logout blacklists the caller's current jti so the token can't be reused
+// even though it hasn't expired yet.
+func (h *AuthHandler) Logout(c *gin.Context) {
+	if jti := c.GetString("jti"); jti != "" {
+		h.db.Create(&models.TokenBlacklist{
+			JTI:       jti,
+			ExpiresAt: time.Now().Add(auth.AccessTokenTTL),
+		})
+	}
+
+	c.SetCookie(tokenCookieName, "", -1, "/", "", false, true)
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var user models.User
+	if err := h.db.First(&user, userID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// Refresh rotates the caller's token ahead of expiry, blacklisting the old
+// jti so a leaked-but-unexpired token can't be replayed after rotation.
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	userID := c.GetUint("userID")
+
+	var user models.User
+	if err := h.db.First(&user, userID).Error; err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	if oldJTI := c.GetString("jti"); oldJTI != "" {
+		h.db.Create(&models.TokenBlacklist{
+			JTI:       oldJTI,
+			ExpiresAt: time.Now().Add(auth.AccessTokenTTL),
+		})
+	}
+
+	h.issueToken(c, http.StatusOK, user)
+}
+
+func (h *AuthHandler) issueToken(c *gin.Context, status int, user models.User) {
+	token, _, err := auth.GenerateToken(h.cfg.JWTSecret, user.ID, user.Role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue token"})
+		return
+	}
+
+	c.SetCookie(tokenCookieName, token, int(auth.AccessTokenTTL.Seconds()), "/", "", false, true)
+	c.JSON(status, gin.H{"user": user, "token": token})
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"hubpay/internal/ledger"
+	"hubpay/internal/models"
+	"hubpay/internal/services"
+)
+
+type CommissionHandler struct {
+	db      *gorm.DB
+	service *services.CommissionService
+	ledger  *ledger.Ledger
+}
+
+func NewCommissionHandler(db *gorm.DB, service *services.CommissionService, lg *ledger.Ledger) *CommissionHandler {
+	return &CommissionHandler{db: db, service: service, ledger: lg}
+}
+
+func (h *CommissionHandler) GetCommissions(c *gin.Context) {
+	var commissions []models.Commission
+	if err := h.db.Preload("AE").Preload("Invoice").Find(&commissions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch commissions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, commissions)
+}
+
+func (h *CommissionHandler) UpdateCommissionStatus(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid commission ID"})
+		return
+	}
+
+	var req models.UpdateCommissionStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actorID := c.GetUint("userID")
+	commission, err := h.service.UpdateStatus(uint(id), req.Status, actorID, req.RejectionReason)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, commission)
+}
+
+// RecomputeCommission re-derives a single commission's bonuses and OTE cap
+// from its AE's current commission config.
+func (h *CommissionHandler) RecomputeCommission(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid commission ID"})
+		return
+	}
+
+	var req models.RecomputeCommissionRequest
+	_ = c.ShouldBindJSON(&req)
+
+	actorID := c.GetUint("userID")
+	commission, err := h.service.Recompute(uint(id), actorID, req.Reason)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, commission)
+}
+
+// RecomputeCommissions re-derives every non-paid commission in a date
+// range, optionally restricted to one AE, continuing past individual
+// failures so a single already-paid commission doesn't block the batch.
+func (h *CommissionHandler) RecomputeCommissions(c *gin.Context) {
+	from, err := time.Parse("2006-01-02", c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date"})
+		return
+	}
+	to, err := time.Parse("2006-01-02", c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date"})
+		return
+	}
+
+	var aeID *uint
+	if raw := c.Query("aeId"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid aeId"})
+			return
+		}
+		id := uint(parsed)
+		aeID = &id
+	}
+
+	actorID := c.GetUint("userID")
+	commissions, err := h.service.RecomputeRange(aeID, from, to, actorID, c.Query("reason"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, commissions)
+}
+
+// GetCommissionJournal returns the full append-only ledger history behind a
+// single commission, proving how its current status was reached.
+func (h *CommissionHandler) GetCommissionJournal(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid commission ID"})
+		return
+	}
+
+	entries, err := h.ledger.JournalForCommission(uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch journal"})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+func (h *CommissionHandler) GetAECommissionConfig(c *gin.Context) {
+	userID := c.GetUint("userID")
+
+	var assignment models.AECommissionAssignment
+	query := h.db.Preload("CommissionConfig").
+		Where("ae_id = ? AND start_date <= ?", userID, time.Now()).
+		Where("end_date IS NULL OR end_date > ?", time.Now()).
+		Order("start_date DESC")
+
+	if err := query.First(&assignment).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No active commission configuration found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, assignment.CommissionConfig)
+}
@@ -1,20 +1,26 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 	"hubpay/internal/models"
+	"hubpay/internal/scheduler"
 )
 
+const contractDateLayout = "2006-01-02"
+
 type ContractHandler struct {
-	db *gorm.DB
+	db        *gorm.DB
+	scheduler *scheduler.Scheduler
 }
 
-func NewContractHandler(db *gorm.DB) *ContractHandler {
-	return &ContractHandler{db: db}
+func NewContractHandler(db *gorm.DB, s *scheduler.Scheduler) *ContractHandler {
+	return &ContractHandler{db: db, scheduler: s}
 }
 
 func (h *ContractHandler) GetContracts(c *gin.Context) {
@@ -36,16 +42,35 @@ func (h *ContractHandler) CreateContract(c *gin.Context) {
 
 	userID := c.GetUint("userID")
 
+	agreementStartDate, err := time.Parse(contractDateLayout, req.AgreementStartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid agreementStartDate"})
+		return
+	}
+	var agreementEndDate *time.Time
+	if req.AgreementEndDate != "" {
+		parsed, err := time.Parse(contractDateLayout, req.AgreementEndDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid agreementEndDate"})
+			return
+		}
+		agreementEndDate = &parsed
+	}
+
 	contract := models.Contract{
-		ClientName:     req.ClientName,
-		AEID:           req.AEID,
-		ContractValue:  req.ContractValue,
-		ACV:            req.ACV,
-		ContractType:   req.ContractType,
-		ContractLength: req.ContractLength,
-		PaymentTerms:   req.PaymentTerms,
-		IsPilot:        req.IsPilot,
-		CreatedBy:      userID,
+		ClientName:         req.ClientName,
+		AEID:               req.AEID,
+		ContractValue:      req.ContractValue,
+		ACV:                req.ACV,
+		ContractType:       req.ContractType,
+		ContractLength:     req.ContractLength,
+		PaymentTerms:       req.PaymentTerms,
+		IsPilot:            req.IsPilot,
+		AgreementStartDate: agreementStartDate,
+		AgreementEndDate:   agreementEndDate,
+		NoEndingDate:       req.NoEndingDate,
+		IsOneTime:          req.IsOneTime,
+		CreatedBy:          userID,
 	}
 
 	if err := h.db.Create(&contract).Error; err != nil {
@@ -53,6 +78,12 @@ func (h *ContractHandler) CreateContract(c *gin.Context) {
 		return
 	}
 
+	if !contract.IsOneTime {
+		if _, err := h.scheduler.CreateSchedule(&contract); err != nil {
+			log.Printf("contract %d: failed to create invoice schedule: %v", contract.ID, err)
+		}
+	}
+
 	// Load the AE information
 	h.db.Preload("AE").First(&contract, contract.ID)
 
@@ -85,4 +116,4 @@ func (h *ContractHandler) DeleteContract(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Contract deleted successfully"})
-}
\ No newline at end of file
+}
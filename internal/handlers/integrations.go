@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"hubpay/internal/integrations"
+)
+
+// IntegrationsHandler exposes admin operations over whichever
+// integrations.BillingProvider adapters are registered, without needing to
+// know which providers exist.
+type IntegrationsHandler struct {
+	db         *gorm.DB
+	reconciler *integrations.Reconciler
+}
+
+func NewIntegrationsHandler(db *gorm.DB, reconciler *integrations.Reconciler) *IntegrationsHandler {
+	return &IntegrationsHandler{db: db, reconciler: reconciler}
+}
+
+// SyncInvoice pushes an invoice to a billing provider (default "tabs" for
+// backward compatibility with the existing TabsInvoiceID field) and starts
+// tracking it for reconciliation.
+func (h *IntegrationsHandler) SyncInvoice(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invoice ID"})
+		return
+	}
+
+	provider := c.Query("provider")
+	if provider == "" {
+		provider = "tabs"
+	}
+
+	sync, err := h.reconciler.Push(c.Request.Context(), provider, uint(id))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sync)
+}
+
+// GetProviderStatus returns how many invoice syncs are pending, synced, or
+// failed for one provider.
+func (h *IntegrationsHandler) GetProviderStatus(c *gin.Context) {
+	status, err := h.reconciler.Status(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
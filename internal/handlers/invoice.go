@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"hubpay/internal/models"
+	"hubpay/internal/services"
+)
+
+type InvoiceHandler struct {
+	db         *gorm.DB
+	commission *services.CommissionService
+}
+
+func NewInvoiceHandler(db *gorm.DB, commissionService *services.CommissionService) *InvoiceHandler {
+	return &InvoiceHandler{db: db, commission: commissionService}
+}
+
+func (h *InvoiceHandler) GetInvoices(c *gin.Context) {
+	var invoices []models.Invoice
+	if err := h.db.Preload("Contract").Find(&invoices).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch invoices"})
+		return
+	}
+
+	c.JSON(http.StatusOK, invoices)
+}
+
+// CreateInvoice books an invoice and immediately calculates the commission
+// it generates, the same sequence webhooks.handleTabsInvoicePaid follows for
+// invoices created by a billing provider's push notification.
+func (h *InvoiceHandler) CreateInvoice(c *gin.Context) {
+	var req models.CreateInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	invoiceDate, err := time.Parse(contractDateLayout, req.InvoiceDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invoiceDate"})
+		return
+	}
+
+	invoice := models.Invoice{
+		ContractID:  req.ContractID,
+		Amount:      req.Amount,
+		InvoiceDate: invoiceDate,
+		RevenueType: req.RevenueType,
+		CreatedBy:   c.GetUint("userID"),
+	}
+
+	if err := h.db.Create(&invoice).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create invoice"})
+		return
+	}
+
+	if _, err := h.commission.CalculateCommission(invoice); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate commission"})
+		return
+	}
+
+	h.db.Preload("Contract").First(&invoice, invoice.ID)
+
+	c.JSON(http.StatusCreated, invoice)
+}
+
+// GetTabsInvoices lists invoices that carry a Tabs invoice ID: those created
+// from a Tabs "invoice paid" webhook and those pushed to Tabs through the
+// billing sync, for the Tabs-specific admin view.
+func (h *InvoiceHandler) GetTabsInvoices(c *gin.Context) {
+	var invoices []models.Invoice
+	if err := h.db.Preload("Contract").Where("tabs_invoice_id != ?", "").Find(&invoices).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch Tabs invoices"})
+		return
+	}
+
+	c.JSON(http.StatusOK, invoices)
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"hubpay/internal/models"
+	"hubpay/internal/services"
+)
+
+const payrollDateLayout = "2006-01-02"
+
+type PayrollHandler struct {
+	db      *gorm.DB
+	service *services.PayrollService
+}
+
+func NewPayrollHandler(db *gorm.DB, service *services.PayrollService) *PayrollHandler {
+	return &PayrollHandler{db: db, service: service}
+}
+
+func (h *PayrollHandler) CreatePayroll(c *gin.Context) {
+	var req models.CreatePayrollRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	periodStart, err := time.Parse(payrollDateLayout, req.PeriodStart)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid periodStart"})
+		return
+	}
+	periodEnd, err := time.Parse(payrollDateLayout, req.PeriodEnd)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid periodEnd"})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	payroll, err := h.service.CreatePayroll(req, periodStart, periodEnd, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, payroll)
+}
+
+func (h *PayrollHandler) ApprovePayroll(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payroll ID"})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	payroll, err := h.service.Approve(uint(id), userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, payroll)
+}
+
+func (h *PayrollHandler) PayPayroll(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payroll ID"})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	payroll, err := h.service.Pay(uint(id), userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, payroll)
+}
+
+func (h *PayrollHandler) ExportPayroll(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payroll ID"})
+		return
+	}
+
+	data, err := h.service.ExportCSV(uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=payroll-"+c.Param("id")+".csv")
+	c.Data(http.StatusOK, "text/csv", data)
+}
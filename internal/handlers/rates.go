@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"hubpay/internal/models"
+	"hubpay/internal/rates"
+)
+
+const exchangeRateDateLayout = "2006-01-02"
+
+// RatesHandler exposes admin operations for the rates package, letting
+// admins review and correct historical exchange rates used to normalize
+// commissions into the reporting currency.
+type RatesHandler struct {
+	db      *gorm.DB
+	service *rates.Service
+}
+
+func NewRatesHandler(db *gorm.DB, service *rates.Service) *RatesHandler {
+	return &RatesHandler{db: db, service: service}
+}
+
+// ListRates returns every recorded rate for a currency pair, most recent
+// first.
+func (h *RatesHandler) ListRates(c *gin.Context) {
+	base := c.Query("base")
+	quote := c.Query("quote")
+	if base == "" || quote == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "base and quote are required"})
+		return
+	}
+
+	list, err := h.service.ListRates(base, quote)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// RecordRate manually enters or corrects a rate for a given date.
+func (h *RatesHandler) RecordRate(c *gin.Context) {
+	var req models.RecordExchangeRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	asOf, err := time.Parse(exchangeRateDateLayout, req.AsOf)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid asOf"})
+		return
+	}
+
+	rate, err := h.service.RecordRate(req.BaseCurrency, req.QuoteCurrency, req.Rate, asOf)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, rate)
+}
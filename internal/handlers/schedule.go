@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"hubpay/internal/models"
+	"hubpay/internal/scheduler"
+)
+
+// ScheduleHandler exposes admin operations on a contract's recurring
+// invoice schedule: previewing upcoming periods, cancelling future
+// generation, and backfilling periods missed during an outage.
+type ScheduleHandler struct {
+	db        *gorm.DB
+	scheduler *scheduler.Scheduler
+}
+
+func NewScheduleHandler(db *gorm.DB, s *scheduler.Scheduler) *ScheduleHandler {
+	return &ScheduleHandler{db: db, scheduler: s}
+}
+
+func (h *ScheduleHandler) GetUpcomingInvoices(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	count := 12
+	if raw := c.Query("count"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid count"})
+			return
+		}
+		count = parsed
+	}
+
+	dates, err := h.scheduler.PreviewUpcoming(uint(id), count)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Schedule not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"upcoming": dates})
+}
+
+func (h *ScheduleHandler) CancelSchedule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	var req models.CancelScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.scheduler.CancelSchedule(uint(id), req.Reason); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel schedule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Schedule cancelled"})
+}
+
+func (h *ScheduleHandler) BackfillSchedule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	generated, err := h.scheduler.Backfill(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"generated": generated})
+}
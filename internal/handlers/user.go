@@ -34,4 +34,4 @@ func (h *UserHandler) GetAEs(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, aes)
-}
\ No newline at end of file
+}
@@ -0,0 +1,148 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"hubpay/internal/models"
+)
+
+const defaultConnectWiseBaseURL = "https://api.connectwisedev.com/v4_6_release/apis/3.0"
+
+// ConnectWiseProvider syncs against ConnectWise Agreements, whose Company/
+// Contact/StartDate/EndDate/ApplicationCycle/CancelledFlag fields map
+// directly onto the agreement-style fields chunk1-2 added to Contract.
+type ConnectWiseProvider struct {
+	apiKey     string
+	companyID  string
+	baseURL    string
+	httpClient *http.Client
+}
+
+func NewConnectWiseProvider(apiKey, companyID string) *ConnectWiseProvider {
+	return &ConnectWiseProvider{
+		apiKey:     apiKey,
+		companyID:  companyID,
+		baseURL:    defaultConnectWiseBaseURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *ConnectWiseProvider) Name() string { return "connectwise" }
+
+type cwInvoicePushRequest struct {
+	AgreementExternalRef string  `json:"agreementId"`
+	Amount               float64 `json:"amount"`
+	Date                 string  `json:"date"`
+}
+
+type cwInvoicePushResponse struct {
+	ID int `json:"id"`
+}
+
+func (p *ConnectWiseProvider) PushInvoice(ctx context.Context, invoice models.Invoice, contract models.Contract) (string, error) {
+	body, err := json.Marshal(cwInvoicePushRequest{
+		AgreementExternalRef: fmt.Sprintf("%d", contract.ID),
+		Amount:               float64(invoice.Amount) / 100,
+		Date:                 invoice.InvoiceDate.Format("2006-01-02"),
+	})
+	if err != nil {
+		return "", fmt.Errorf("connectwise: marshal push request: %w", err)
+	}
+
+	var resp cwInvoicePushResponse
+	if err := p.do(ctx, http.MethodPost, "/finance/invoices", body, &resp); err != nil {
+		return "", fmt.Errorf("connectwise: push invoice: %w", err)
+	}
+	return fmt.Sprintf("%d", resp.ID), nil
+}
+
+type cwInvoiceStatusResponse struct {
+	ID            int     `json:"id"`
+	InvoiceStatus string  `json:"invoiceStatus"`
+	AmountPaid    float64 `json:"amountPaid"`
+}
+
+func (p *ConnectWiseProvider) PullInvoiceStatus(ctx context.Context, externalID string) (*InvoiceStatus, error) {
+	var resp cwInvoiceStatusResponse
+	if err := p.do(ctx, http.MethodGet, "/finance/invoices/"+externalID, nil, &resp); err != nil {
+		return nil, fmt.Errorf("connectwise: pull invoice status: %w", err)
+	}
+
+	return &InvoiceStatus{
+		ExternalID: fmt.Sprintf("%d", resp.ID),
+		Status:     resp.InvoiceStatus,
+		AmountPaid: int64(resp.AmountPaid * 100),
+		SyncedAt:   time.Now(),
+	}, nil
+}
+
+// cwAgreement is ConnectWise's Agreement shape: Company/Contact identify
+// who the agreement is with, ApplicationCycle is the billing cadence, and
+// CancelledFlag marks it closed out.
+type cwAgreement struct {
+	ID               int        `json:"id"`
+	Company          cwCompany  `json:"company"`
+	Contact          cwContact  `json:"contact"`
+	StartDate        time.Time  `json:"startDate"`
+	EndDate          *time.Time `json:"endDate,omitempty"`
+	ApplicationCycle string     `json:"applicationCycle"`
+	CancelledFlag    bool       `json:"cancelledFlag"`
+}
+
+type cwCompany struct {
+	Name string `json:"name"`
+}
+
+type cwContact struct {
+	Name string `json:"name"`
+}
+
+func (p *ConnectWiseProvider) ListAgreements(ctx context.Context) ([]Agreement, error) {
+	var resp []cwAgreement
+	if err := p.do(ctx, http.MethodGet, "/finance/agreements", nil, &resp); err != nil {
+		return nil, fmt.Errorf("connectwise: list agreements: %w", err)
+	}
+
+	agreements := make([]Agreement, 0, len(resp))
+	for _, a := range resp {
+		agreements = append(agreements, Agreement{
+			ExternalID:       fmt.Sprintf("%d", a.ID),
+			Company:          a.Company.Name,
+			Contact:          a.Contact.Name,
+			StartDate:        a.StartDate,
+			EndDate:          a.EndDate,
+			ApplicationCycle: a.ApplicationCycle,
+			Cancelled:        a.CancelledFlag,
+		})
+	}
+	return agreements, nil
+}
+
+func (p *ConnectWiseProvider) do(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, p.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("clientId", p.companyID)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,94 @@
+// Package integrations defines a provider-agnostic interface for syncing
+// HubPay contracts and invoices with external billing systems (Tabs,
+// ConnectWise Agreements, and so on), plus a reconciliation worker that
+// keeps local invoice state in sync with whatever those providers report.
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"hubpay/internal/models"
+)
+
+// Agreement is a remote billing system's view of a contract: the fields
+// ConnectWise Agreements (and similarly-shaped providers) track against a
+// HubPay Contract.
+type Agreement struct {
+	ExternalID       string
+	Company          string
+	Contact          string
+	StartDate        time.Time
+	EndDate          *time.Time
+	ApplicationCycle string
+	Cancelled        bool
+}
+
+// InvoiceStatus is what a provider reports back for one pushed invoice.
+type InvoiceStatus struct {
+	ExternalID string
+	Status     string
+	AmountPaid int64
+	SyncedAt   time.Time
+}
+
+// BillingProvider is implemented by each external billing integration.
+// Adapters are registered independently via Registry.Register, so adding a
+// new provider never requires touching handler or reconciler code.
+type BillingProvider interface {
+	// Name is the provider's registry key, e.g. "tabs" or "connectwise".
+	Name() string
+	// PushInvoice sends a HubPay invoice to the provider and returns the
+	// ID it was assigned there.
+	PushInvoice(ctx context.Context, invoice models.Invoice, contract models.Contract) (externalID string, err error)
+	// PullInvoiceStatus fetches the provider's current view of a
+	// previously pushed invoice.
+	PullInvoiceStatus(ctx context.Context, externalID string) (*InvoiceStatus, error)
+	// ListAgreements lists the provider's contracts/agreements, for
+	// reconciling against HubPay Contracts.
+	ListAgreements(ctx context.Context) ([]Agreement, error)
+}
+
+// Registry looks up a BillingProvider by name. Adapters register
+// themselves with it at startup instead of being switched on by name
+// anywhere in handler code.
+type Registry struct {
+	mu        sync.RWMutex
+	providers map[string]BillingProvider
+}
+
+func NewRegistry() *Registry {
+	return &Registry{providers: make(map[string]BillingProvider)}
+}
+
+// Register adds a provider under its own Name(), overwriting any prior
+// registration under that name.
+func (r *Registry) Register(p BillingProvider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[p.Name()] = p
+}
+
+// Get looks up a provider by name.
+func (r *Registry) Get(name string) (BillingProvider, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("integrations: no provider registered as %q", name)
+	}
+	return p, nil
+}
+
+// Names lists every registered provider.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,210 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+
+	"hubpay/internal/models"
+)
+
+// Backoff bounds applied to a failed pull, doubling per attempt like
+// webhooks.Handler's inbound retry queue.
+const (
+	initialRetryBackoff = 1 * time.Minute
+	maxRetryBackoff     = 1 * time.Hour
+)
+
+// syncDiff is the structured before/after comparison written to
+// Invoice.SyncDetails after each successful pull.
+type syncDiff struct {
+	Provider       string    `json:"provider"`
+	ExternalID     string    `json:"externalId"`
+	PreviousStatus string    `json:"previousStatus"`
+	CurrentStatus  string    `json:"currentStatus"`
+	AmountPaid     int64     `json:"amountPaid"`
+	SyncedAt       time.Time `json:"syncedAt"`
+}
+
+// Reconciler periodically pulls invoice status from every registered
+// BillingProvider and reconciles it against local Invoice rows.
+type Reconciler struct {
+	db       *gorm.DB
+	registry *Registry
+}
+
+func NewReconciler(db *gorm.DB, registry *Registry) *Reconciler {
+	return &Reconciler{db: db, registry: registry}
+}
+
+// Push sends one invoice to a provider and records the resulting
+// InvoiceSync row, creating it in `pending` status so ReconcileDue will
+// pick it up on the next tick.
+func (r *Reconciler) Push(ctx context.Context, providerName string, invoiceID uint) (*models.InvoiceSync, error) {
+	provider, err := r.registry.Get(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	var invoice models.Invoice
+	if err := r.db.WithContext(ctx).Preload("Contract").First(&invoice, invoiceID).Error; err != nil {
+		return nil, fmt.Errorf("invoice not found: %w", err)
+	}
+
+	externalID, err := provider.PushInvoice(ctx, invoice, invoice.Contract)
+	if err != nil {
+		return nil, fmt.Errorf("push invoice to %s: %w", providerName, err)
+	}
+
+	sync := models.InvoiceSync{
+		InvoiceID:     invoiceID,
+		Provider:      providerName,
+		ExternalID:    externalID,
+		Status:        "pending",
+		NextAttemptAt: time.Now(),
+	}
+	if err := r.db.WithContext(ctx).
+		Where(models.InvoiceSync{InvoiceID: invoiceID, Provider: providerName}).
+		Assign(models.InvoiceSync{ExternalID: externalID, Status: "pending", NextAttemptAt: time.Now(), Attempt: 0}).
+		FirstOrCreate(&sync).Error; err != nil {
+		return nil, fmt.Errorf("record invoice sync: %w", err)
+	}
+
+	if providerName == "tabs" {
+		r.db.WithContext(ctx).Model(&invoice).Update("tabs_invoice_id", externalID)
+	}
+
+	return &sync, nil
+}
+
+// Run polls for due syncs every tick until ctx is cancelled.
+func (r *Reconciler) Run(ctx context.Context, tick time.Duration) {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.ReconcileDue(ctx); err != nil {
+				log.Printf("integrations: reconcile due syncs: %v", err)
+			}
+		}
+	}
+}
+
+// ReconcileDue pulls the current status for every InvoiceSync due a pull,
+// writing a structured diff to Invoice.SyncDetails on success and
+// scheduling a backed-off retry on failure.
+func (r *Reconciler) ReconcileDue(ctx context.Context) error {
+	var syncs []models.InvoiceSync
+	if err := r.db.WithContext(ctx).Preload("Invoice").
+		Where("next_attempt_at <= ?", time.Now()).
+		Find(&syncs).Error; err != nil {
+		return fmt.Errorf("load due invoice syncs: %w", err)
+	}
+
+	for i := range syncs {
+		if err := r.reconcileOne(ctx, &syncs[i]); err != nil {
+			log.Printf("integrations: reconcile invoice %d via %s: %v", syncs[i].InvoiceID, syncs[i].Provider, err)
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) reconcileOne(ctx context.Context, sync *models.InvoiceSync) error {
+	provider, err := r.registry.Get(sync.Provider)
+	if err != nil {
+		return err
+	}
+
+	status, err := provider.PullInvoiceStatus(ctx, sync.ExternalID)
+	if err != nil {
+		return r.scheduleRetry(ctx, sync, err)
+	}
+
+	var previous syncDiff
+	_ = json.Unmarshal([]byte(sync.Invoice.SyncDetails), &previous)
+
+	diff := syncDiff{
+		Provider:       sync.Provider,
+		ExternalID:     sync.ExternalID,
+		PreviousStatus: previous.CurrentStatus,
+		CurrentStatus:  status.Status,
+		AmountPaid:     status.AmountPaid,
+		SyncedAt:       status.SyncedAt,
+	}
+	diffJSON, err := json.Marshal(diff)
+	if err != nil {
+		return fmt.Errorf("marshal sync diff: %w", err)
+	}
+
+	now := time.Now()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Invoice{}).Where("id = ?", sync.InvoiceID).
+			Update("sync_details", string(diffJSON)).Error; err != nil {
+			return err
+		}
+		return tx.Model(sync).Updates(map[string]interface{}{
+			"status":          "synced",
+			"last_synced_at":  now,
+			"attempt":         0,
+			"last_error":      "",
+			"next_attempt_at": now.Add(24 * time.Hour),
+		}).Error
+	})
+}
+
+// scheduleRetry doubles the backoff (capped at maxRetryBackoff) on each
+// consecutive failure, mirroring webhooks.Handler.Retry.
+func (r *Reconciler) scheduleRetry(ctx context.Context, sync *models.InvoiceSync, cause error) error {
+	backoff := initialRetryBackoff << sync.Attempt
+	if backoff <= 0 || backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+
+	return r.db.WithContext(ctx).Model(sync).Updates(map[string]interface{}{
+		"status":          "failed",
+		"attempt":         sync.Attempt + 1,
+		"last_error":      cause.Error(),
+		"next_attempt_at": time.Now().Add(backoff),
+	}).Error
+}
+
+// Status summarizes one provider's outstanding syncs for the admin status
+// endpoint.
+type Status struct {
+	Provider string `json:"provider"`
+	Pending  int64  `json:"pending"`
+	Synced   int64  `json:"synced"`
+	Failed   int64  `json:"failed"`
+}
+
+func (r *Reconciler) Status(ctx context.Context, providerName string) (*Status, error) {
+	if _, err := r.registry.Get(providerName); err != nil {
+		return nil, err
+	}
+
+	status := &Status{Provider: providerName}
+	counts := []struct {
+		status string
+		dest   *int64
+	}{
+		{"pending", &status.Pending},
+		{"synced", &status.Synced},
+		{"failed", &status.Failed},
+	}
+	for _, c := range counts {
+		if err := r.db.WithContext(ctx).Model(&models.InvoiceSync{}).
+			Where("provider = ? AND status = ?", providerName, c.status).
+			Count(c.dest).Error; err != nil {
+			return nil, fmt.Errorf("count %s syncs: %w", c.status, err)
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,134 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"hubpay/internal/models"
+)
+
+const defaultTabsBaseURL = "https://api.tabs.inc/v1"
+
+// TabsProvider pushes invoices to and pulls invoice/agreement status from
+// Tabs' billing API.
+type TabsProvider struct {
+	apiKey     string
+	baseURL    string
+	httpClient *http.Client
+}
+
+func NewTabsProvider(apiKey string) *TabsProvider {
+	return &TabsProvider{
+		apiKey:     apiKey,
+		baseURL:    defaultTabsBaseURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *TabsProvider) Name() string { return "tabs" }
+
+type tabsInvoicePushRequest struct {
+	ContractExternalRef string `json:"contractExternalRef"`
+	AmountCents         int64  `json:"amountCents"`
+	InvoiceDate         string `json:"invoiceDate"`
+}
+
+type tabsInvoicePushResponse struct {
+	ID string `json:"id"`
+}
+
+func (p *TabsProvider) PushInvoice(ctx context.Context, invoice models.Invoice, contract models.Contract) (string, error) {
+	body, err := json.Marshal(tabsInvoicePushRequest{
+		ContractExternalRef: fmt.Sprintf("%d", contract.ID),
+		AmountCents:         invoice.Amount,
+		InvoiceDate:         invoice.InvoiceDate.Format("2006-01-02"),
+	})
+	if err != nil {
+		return "", fmt.Errorf("tabs: marshal push request: %w", err)
+	}
+
+	var resp tabsInvoicePushResponse
+	if err := p.do(ctx, http.MethodPost, "/invoices", body, &resp); err != nil {
+		return "", fmt.Errorf("tabs: push invoice: %w", err)
+	}
+	return resp.ID, nil
+}
+
+type tabsInvoiceStatusResponse struct {
+	ID          string `json:"id"`
+	Status      string `json:"status"`
+	AmountPaid  int64  `json:"amountPaidCents"`
+	LastUpdated string `json:"lastUpdated"`
+}
+
+func (p *TabsProvider) PullInvoiceStatus(ctx context.Context, externalID string) (*InvoiceStatus, error) {
+	var resp tabsInvoiceStatusResponse
+	if err := p.do(ctx, http.MethodGet, "/invoices/"+externalID, nil, &resp); err != nil {
+		return nil, fmt.Errorf("tabs: pull invoice status: %w", err)
+	}
+
+	return &InvoiceStatus{
+		ExternalID: resp.ID,
+		Status:     resp.Status,
+		AmountPaid: resp.AmountPaid,
+		SyncedAt:   time.Now(),
+	}, nil
+}
+
+type tabsAgreement struct {
+	ID               string     `json:"id"`
+	Company          string     `json:"company"`
+	Contact          string     `json:"contact"`
+	StartDate        time.Time  `json:"startDate"`
+	EndDate          *time.Time `json:"endDate,omitempty"`
+	ApplicationCycle string     `json:"applicationCycle"`
+	Cancelled        bool       `json:"cancelled"`
+}
+
+func (p *TabsProvider) ListAgreements(ctx context.Context) ([]Agreement, error) {
+	var resp []tabsAgreement
+	if err := p.do(ctx, http.MethodGet, "/agreements", nil, &resp); err != nil {
+		return nil, fmt.Errorf("tabs: list agreements: %w", err)
+	}
+
+	agreements := make([]Agreement, 0, len(resp))
+	for _, a := range resp {
+		agreements = append(agreements, Agreement{
+			ExternalID:       a.ID,
+			Company:          a.Company,
+			Contact:          a.Contact,
+			StartDate:        a.StartDate,
+			EndDate:          a.EndDate,
+			ApplicationCycle: a.ApplicationCycle,
+			Cancelled:        a.Cancelled,
+		})
+	}
+	return agreements, nil
+}
+
+func (p *TabsProvider) do(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, p.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
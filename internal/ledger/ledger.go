@@ -0,0 +1,81 @@
+// Package ledger implements a double-entry audit trail for commission
+// events. Commission totals on the models.Commission row are a snapshot;
+// the ledger is the append-only history of how that snapshot was derived
+// (recomputation, clawback, OTE cap decisions, and so on).
+package ledger
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Account types a commission amount can sit in. Every JournalEntry debits
+// one of these and credits another for the same AE.
+const (
+	AccountAccrued    = "accrued"
+	AccountApproved   = "approved"
+	AccountPaid       = "paid"
+	AccountClawback   = "clawback"
+	AccountOTEReserve = "ote_reserve"
+)
+
+// Event types recorded on a JournalEntry, describing why the entry exists.
+const (
+	EventCalculated = "calculated"
+	EventApproved   = "approved"
+	EventRejected   = "rejected"
+	EventPaid       = "paid"
+	EventRecomputed = "recomputed"
+)
+
+// ErrImmutable is returned when code attempts to modify or delete a journal
+// entry after it has been written.
+var ErrImmutable = errors.New("ledger: journal entries are append-only")
+
+// Account registers a valid (ae_id, account_type) pair. Balances are never
+// stored here; they're derived from JournalEntry at read time.
+type Account struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	AEID        uint      `gorm:"not null;uniqueIndex:idx_ledger_accounts_ae_type" json:"aeId"`
+	AccountType string    `gorm:"not null;uniqueIndex:idx_ledger_accounts_ae_type" json:"accountType"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// JournalEntry is one append-only double-entry line: amount_cents moves out
+// of CreditAccount and into DebitAccount for the same AE. TransactionID
+// groups entries written together so a caller can prove a given transition
+// balanced as a whole, even though each row is already self-balancing.
+type JournalEntry struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	TransactionID string    `gorm:"not null;index" json:"transactionId"`
+	CommissionID  uint      `gorm:"not null;index" json:"commissionId"`
+	AEID          uint      `gorm:"not null;index" json:"aeId"`
+	EventType     string    `gorm:"not null" json:"eventType"`
+	DebitAccount  string    `gorm:"not null" json:"debitAccount"`
+	CreditAccount string    `gorm:"not null" json:"creditAccount"`
+	AmountCents   int64     `gorm:"not null" json:"amountCents"`
+	Metadata      string    `json:"metadata,omitempty"`
+	CreatedBy     uint      `json:"createdBy"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// BeforeUpdate rejects any attempt to mutate a journal entry once written;
+// corrections must be a new, offsetting entry instead.
+func (JournalEntry) BeforeUpdate(tx *gorm.DB) error {
+	return ErrImmutable
+}
+
+// BeforeDelete rejects any attempt to delete a journal entry.
+func (JournalEntry) BeforeDelete(tx *gorm.DB) error {
+	return ErrImmutable
+}
+
+// AccountBalance is a trial-balance line: the running balance of one
+// account type for one AE, derived from journal entries as of a point in
+// time.
+type AccountBalance struct {
+	AccountType  string `json:"accountType"`
+	BalanceCents int64  `json:"balanceCents"`
+}
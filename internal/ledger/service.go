@@ -0,0 +1,209 @@
+package ledger
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"hubpay/internal/models"
+)
+
+// Ledger posts and reads commission journal entries.
+type Ledger struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) *Ledger {
+	return &Ledger{db: db}
+}
+
+// Entry is the input shape for one line of a transaction; TransactionID and
+// CreatedAt are filled in by Post.
+type Entry struct {
+	CommissionID  uint
+	AEID          uint
+	EventType     string
+	DebitAccount  string
+	CreditAccount string
+	AmountCents   int64
+	Metadata      string
+	CreatedBy     uint
+}
+
+// Post writes entries as a single transaction group inside tx (the caller's
+// transaction, so the journal write commits or rolls back with whatever
+// state change it's recording). It refuses to write anything if the
+// transaction wouldn't balance.
+func (l *Ledger) Post(tx *gorm.DB, entries ...Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var debits, credits int64
+	for _, e := range entries {
+		debits += e.AmountCents
+		credits += e.AmountCents
+	}
+	if debits != credits {
+		return fmt.Errorf("ledger: unbalanced transaction (debits=%d credits=%d)", debits, credits)
+	}
+
+	transactionID, err := newTransactionID()
+	if err != nil {
+		return fmt.Errorf("ledger: generate transaction id: %w", err)
+	}
+
+	rows := make([]JournalEntry, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, JournalEntry{
+			TransactionID: transactionID,
+			CommissionID:  e.CommissionID,
+			AEID:          e.AEID,
+			EventType:     e.EventType,
+			DebitAccount:  e.DebitAccount,
+			CreditAccount: e.CreditAccount,
+			AmountCents:   e.AmountCents,
+			Metadata:      e.Metadata,
+			CreatedBy:     e.CreatedBy,
+		})
+	}
+
+	db := l.db
+	if tx != nil {
+		db = tx
+	}
+	return db.Create(&rows).Error
+}
+
+// JournalForCommission returns every entry ever written for a commission,
+// oldest first.
+func (l *Ledger) JournalForCommission(commissionID uint) ([]JournalEntry, error) {
+	var entries []JournalEntry
+	err := l.db.Where("commission_id = ?", commissionID).Order("created_at ASC, id ASC").Find(&entries).Error
+	return entries, err
+}
+
+// TrialBalance returns the balance of every account type touched by an AE
+// as of a point in time, proving the books balance to zero net of external
+// inflow (sum of all accrued amounts).
+func (l *Ledger) TrialBalance(aeID uint, asOf time.Time) ([]AccountBalance, error) {
+	accountTypes := []string{AccountAccrued, AccountApproved, AccountPaid, AccountClawback, AccountOTEReserve}
+
+	balances := make([]AccountBalance, 0, len(accountTypes))
+	for _, accountType := range accountTypes {
+		var credited, debited int64
+
+		if err := l.db.Model(&JournalEntry{}).
+			Where("ae_id = ? AND credit_account = ? AND created_at <= ?", aeID, accountType, asOf).
+			Select("COALESCE(SUM(amount_cents), 0)").Scan(&credited).Error; err != nil {
+			return nil, err
+		}
+		if err := l.db.Model(&JournalEntry{}).
+			Where("ae_id = ? AND debit_account = ? AND created_at <= ?", aeID, accountType, asOf).
+			Select("COALESCE(SUM(amount_cents), 0)").Scan(&debited).Error; err != nil {
+			return nil, err
+		}
+
+		balances = append(balances, AccountBalance{
+			AccountType:  accountType,
+			BalanceCents: debited - credited,
+		})
+	}
+
+	return balances, nil
+}
+
+// BackfillOpeningEntries writes the journal entries that would have been
+// posted for every Commission row that predates this ledger (created before
+// CalculateCommission/UpdateStatus started calling Post), so TrialBalance
+// covers an AE's full history instead of silently excluding pre-ledger
+// commissions. It's idempotent: a commission with at least one JournalEntry
+// already is left untouched, so running it more than once (e.g. on every
+// Migrate) is safe.
+//
+// A backfilled commission only has its *current* status to go on, not the
+// sequence of transitions that produced it, so a rejected commission is
+// always backfilled as a single accrued->clawback entry rather than
+// reconstructing an approved->rejected history that may or may not have
+// happened.
+func (l *Ledger) BackfillOpeningEntries() (int, error) {
+	var commissions []models.Commission
+	if err := l.db.Where("id NOT IN (?)", l.db.Model(&JournalEntry{}).Select("DISTINCT commission_id")).
+		Find(&commissions).Error; err != nil {
+		return 0, fmt.Errorf("ledger: load commissions missing journal entries: %w", err)
+	}
+
+	backfilled := 0
+	for _, commission := range commissions {
+		if err := l.db.Transaction(func(tx *gorm.DB) error {
+			if err := l.Post(tx, Entry{
+				CommissionID:  commission.ID,
+				AEID:          commission.AEID,
+				EventType:     EventCalculated,
+				DebitAccount:  AccountAccrued,
+				CreditAccount: AccountOTEReserve,
+				AmountCents:   commission.TotalCommission,
+				Metadata:      "backfilled opening entry",
+			}); err != nil {
+				return err
+			}
+
+			for _, transition := range backfillTransitions(commission.Status) {
+				if err := l.Post(tx, Entry{
+					CommissionID:  commission.ID,
+					AEID:          commission.AEID,
+					EventType:     transition.eventType,
+					DebitAccount:  transition.debit,
+					CreditAccount: transition.credit,
+					AmountCents:   commission.TotalCommission,
+					Metadata:      "backfilled opening entry",
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return backfilled, fmt.Errorf("ledger: backfill commission %d: %w", commission.ID, err)
+		}
+		backfilled++
+	}
+
+	return backfilled, nil
+}
+
+type backfillTransition struct {
+	debit, credit, eventType string
+}
+
+// backfillTransitions is BackfillOpeningEntries' status->account mapping.
+// Unlike services.transitionAccounts (which only ever needs the single
+// transition a live status change just made), a backfilled commission's
+// only known state is where it ended up, so reaching "paid" replays the
+// full pending->approved->paid chain rather than skipping straight to a
+// paid entry that would leave the accrued/approved accounts unbalanced.
+func backfillTransitions(status string) []backfillTransition {
+	switch status {
+	case "approved":
+		return []backfillTransition{{AccountApproved, AccountAccrued, EventApproved}}
+	case "rejected":
+		return []backfillTransition{{AccountClawback, AccountAccrued, EventRejected}}
+	case "paid":
+		return []backfillTransition{
+			{AccountApproved, AccountAccrued, EventApproved},
+			{AccountPaid, AccountApproved, EventPaid},
+		}
+	default:
+		return nil
+	}
+}
+
+func newTransactionID() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
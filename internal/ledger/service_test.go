@@ -0,0 +1,32 @@
+package ledger
+
+import "testing"
+
+func TestBackfillTransitions(t *testing.T) {
+	tests := []struct {
+		status    string
+		wantSteps []backfillTransition
+	}{
+		{"approved", []backfillTransition{{AccountApproved, AccountAccrued, EventApproved}}},
+		{"rejected", []backfillTransition{{AccountClawback, AccountAccrued, EventRejected}}},
+		{"paid", []backfillTransition{
+			{AccountApproved, AccountAccrued, EventApproved},
+			{AccountPaid, AccountApproved, EventPaid},
+		}},
+		{"pending", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			got := backfillTransitions(tt.status)
+			if len(got) != len(tt.wantSteps) {
+				t.Fatalf("backfillTransitions(%q) = %v, want %v", tt.status, got, tt.wantSteps)
+			}
+			for i, step := range got {
+				if step != tt.wantSteps[i] {
+					t.Fatalf("backfillTransitions(%q)[%d] = %v, want %v", tt.status, i, step, tt.wantSteps[i])
+				}
+			}
+		})
+	}
+}
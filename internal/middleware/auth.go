@@ -2,53 +2,71 @@ package middleware
 
 import (
 	"net/http"
-	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+
+	"hubpay/internal/auth"
+	"hubpay/internal/config"
 	"hubpay/internal/models"
 )
 
-func RequireAuth() gin.HandlerFunc {
+const tokenCookieName = "hubpay_token"
+
+// RequireAuth validates the caller's JWT, taken from either the
+// Authorization: Bearer header or the hubpay_token cookie, and sets userID,
+// role, and jti on the gin context for downstream handlers.
+func RequireAuth(cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userID := c.GetHeader("X-User-ID")
-		if userID == "" {
+		tokenString := extractToken(c)
+		if tokenString == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 			c.Abort()
 			return
 		}
 
-		id, err := strconv.ParseUint(userID, 10, 32)
+		claims, err := auth.ParseToken(tokenString, cfg.JWTSecret)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid user ID"})
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			c.Abort()
+			return
+		}
+
+		db := c.MustGet("db").(*gorm.DB)
+		var revoked models.TokenBlacklist
+		if err := db.Where("jti = ?", claims.ID).First(&revoked).Error; err == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
 			c.Abort()
 			return
 		}
 
-		c.Set("userID", uint(id))
+		c.Set("userID", claims.UserID)
+		c.Set("role", claims.Role)
+		c.Set("jti", claims.ID)
 		c.Next()
 	}
 }
 
+// RequireAdmin rejects the request unless RequireAuth already resolved an
+// admin role from the token claims. It must run after RequireAuth.
 func RequireAdmin() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		db := c.MustGet("db").(*gorm.DB)
-		userID := c.GetUint("userID")
-
-		var user models.User
-		if err := db.First(&user, userID).Error; err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
-			c.Abort()
-			return
-		}
-
-		if user.Role != "admin" {
+		if c.GetString("role") != "admin" {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
 			c.Abort()
 			return
 		}
-
-		c.Set("user", user)
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+
+func extractToken(c *gin.Context) string {
+	if header := c.GetHeader("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		return strings.TrimPrefix(header, "Bearer ")
+	}
+	if cookie, err := c.Cookie(tokenCookieName); err == nil {
+		return cookie
+	}
+	return ""
+}
@@ -37,4 +37,4 @@ func Sessions(sessionSecret string, db *gorm.DB) gin.HandlerFunc {
 		c.Set("db", db)
 		c.Next()
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,59 @@
+package rates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const defaultECBBaseURL = "https://api.frankfurter.app"
+
+// ECBFetcher fetches daily reference rates published by the European
+// Central Bank (via the frankfurter.app mirror, which serves ECB data
+// without requiring an API key).
+type ECBFetcher struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func NewECBFetcher() *ECBFetcher {
+	return &ECBFetcher{
+		baseURL:    defaultECBBaseURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type ecbRateResponse struct {
+	Rates map[string]float64 `json:"rates"`
+}
+
+func (f *ECBFetcher) FetchRate(ctx context.Context, base, quote string, asOf time.Time) (float64, error) {
+	url := fmt.Sprintf("%s/%s?from=%s&to=%s", f.baseURL, asOf.Format("2006-01-02"), base, quote)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("ecb: fetch rate: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return 0, fmt.Errorf("ecb: unexpected status %d", resp.StatusCode)
+	}
+
+	var parsed ecbRateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return 0, fmt.Errorf("ecb: decode response: %w", err)
+	}
+
+	rate, ok := parsed.Rates[quote]
+	if !ok {
+		return 0, fmt.Errorf("ecb: no rate returned for %s/%s", base, quote)
+	}
+	return rate, nil
+}
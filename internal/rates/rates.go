@@ -0,0 +1,117 @@
+// Package rates resolves currency conversion rates for commission
+// calculation and reporting, preferring manually-entered rates (for audit
+// correctness) and falling back to a pluggable external fetcher when one
+// is configured.
+package rates
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"hubpay/internal/models"
+)
+
+// ReportingCurrency is the single currency OTE caps and payroll totals are
+// normalized to, regardless of what currency an AE's deals are booked in.
+const ReportingCurrency = "USD"
+
+// Fetcher looks up a currency pair's rate from an external source when no
+// manual rate has been entered for the requested date. Implementations are
+// optional; Service works with a nil Fetcher and simply errors when no
+// manual rate covers the request.
+type Fetcher interface {
+	FetchRate(ctx context.Context, base, quote string, asOf time.Time) (float64, error)
+}
+
+// Service resolves and records exchange rates, preferring the most recent
+// manually-entered ExchangeRate row as of a given date over calling out to
+// an external Fetcher.
+type Service struct {
+	db      *gorm.DB
+	fetcher Fetcher
+}
+
+// New builds a Service. fetcher may be nil, in which case only
+// manually-recorded rates are used.
+func New(db *gorm.DB, fetcher Fetcher) *Service {
+	return &Service{db: db, fetcher: fetcher}
+}
+
+// GetRate resolves the base->quote rate as of asOf: the most recent
+// manual ExchangeRate row on or before asOf, or the configured Fetcher if
+// none exists.
+func (s *Service) GetRate(ctx context.Context, base, quote string, asOf time.Time) (float64, error) {
+	base, quote = normalizeCurrencyPair(base, quote)
+	if base == quote {
+		return 1, nil
+	}
+
+	var rate models.ExchangeRate
+	err := s.db.WithContext(ctx).
+		Where("base_currency = ? AND quote_currency = ? AND as_of <= ?", base, quote, asOf).
+		Order("as_of DESC").First(&rate).Error
+	if err == nil {
+		return rate.Rate, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return 0, fmt.Errorf("load exchange rate: %w", err)
+	}
+
+	if s.fetcher == nil {
+		return 0, fmt.Errorf("rates: no rate on record for %s/%s as of %s and no fetcher configured", base, quote, asOf.Format("2006-01-02"))
+	}
+	return s.fetcher.FetchRate(ctx, base, quote, asOf)
+}
+
+// Convert applies the base->quote rate as of asOf to amount, both in the
+// smallest currency unit (cents).
+func (s *Service) Convert(ctx context.Context, amount int64, base, quote string, asOf time.Time) (int64, error) {
+	rate, err := s.GetRate(ctx, base, quote, asOf)
+	if err != nil {
+		return 0, err
+	}
+	return int64(float64(amount) * rate), nil
+}
+
+// RecordRate manually enters (or corrects) a rate for a given date, the
+// primary way HubPay admins supply rates and fix historical errors.
+func (s *Service) RecordRate(baseCurrency, quoteCurrency string, rate float64, asOf time.Time) (*models.ExchangeRate, error) {
+	baseCurrency, quoteCurrency = normalizeCurrencyPair(baseCurrency, quoteCurrency)
+	entry := &models.ExchangeRate{
+		BaseCurrency:  baseCurrency,
+		QuoteCurrency: quoteCurrency,
+		Rate:          rate,
+		AsOf:          asOf,
+	}
+	// A rate already on record for the same pair and date is overwritten.
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "base_currency"}, {Name: "quote_currency"}, {Name: "as_of"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate"}),
+	}).Create(entry).Error; err != nil {
+		return nil, fmt.Errorf("record exchange rate: %w", err)
+	}
+	return entry, nil
+}
+
+// ListRates returns every recorded rate for a currency pair, most recent
+// first, for audit review.
+func (s *Service) ListRates(baseCurrency, quoteCurrency string) ([]models.ExchangeRate, error) {
+	baseCurrency, quoteCurrency = normalizeCurrencyPair(baseCurrency, quoteCurrency)
+	var rates []models.ExchangeRate
+	err := s.db.Where("base_currency = ? AND quote_currency = ?", baseCurrency, quoteCurrency).
+		Order("as_of DESC").Find(&rates).Error
+	return rates, err
+}
+
+// normalizeCurrencyPair upper-cases both currency codes so callers that
+// supply a differently-cased code than what's stored (e.g. "usd" vs "USD")
+// still match on lookup, and so every row written through Service is
+// consistently-cased going forward.
+func normalizeCurrencyPair(base, quote string) (string, string) {
+	return strings.ToUpper(base), strings.ToUpper(quote)
+}
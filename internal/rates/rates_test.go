@@ -0,0 +1,22 @@
+package rates
+
+import "testing"
+
+func TestNormalizeCurrencyPair(t *testing.T) {
+	tests := []struct {
+		base, quote         string
+		wantBase, wantQuote string
+	}{
+		{"usd", "eur", "USD", "EUR"},
+		{"USD", "EUR", "USD", "EUR"},
+		{"Usd", "eUR", "USD", "EUR"},
+	}
+
+	for _, tt := range tests {
+		gotBase, gotQuote := normalizeCurrencyPair(tt.base, tt.quote)
+		if gotBase != tt.wantBase || gotQuote != tt.wantQuote {
+			t.Fatalf("normalizeCurrencyPair(%q, %q) = (%q, %q), want (%q, %q)",
+				tt.base, tt.quote, gotBase, gotQuote, tt.wantBase, tt.wantQuote)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+// Package scheduler generates recurring Invoice rows from a Contract's
+// agreement terms on the cadence encoded by its PaymentTerms, the pattern
+// ConnectWise Agreements uses to auto-bill an active contract between its
+// start and end dates.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"hubpay/internal/models"
+	"hubpay/internal/services"
+)
+
+// ErrOneTimeContract is returned when a schedule is requested for a
+// contract whose agreement is marked one-time (no recurring cadence).
+var ErrOneTimeContract = errors.New("scheduler: contract is one-time, no recurring schedule")
+
+type Scheduler struct {
+	db         *gorm.DB
+	commission *services.CommissionService
+}
+
+func New(db *gorm.DB, commissionService *services.CommissionService) *Scheduler {
+	return &Scheduler{db: db, commission: commissionService}
+}
+
+// CreateSchedule sets up recurring invoice generation for a contract whose
+// agreement isn't one-time, with the first run at the agreement start
+// date.
+func (s *Scheduler) CreateSchedule(contract *models.Contract) (*models.ContractSchedule, error) {
+	if contract.IsOneTime {
+		return nil, ErrOneTimeContract
+	}
+
+	schedule := &models.ContractSchedule{
+		ContractID: contract.ID,
+		Cadence:    contract.PaymentTerms,
+		NextRunAt:  contract.AgreementStartDate,
+		Status:     "active",
+	}
+	if err := s.db.Create(schedule).Error; err != nil {
+		return nil, fmt.Errorf("create contract schedule: %w", err)
+	}
+	return schedule, nil
+}
+
+// Run polls for due schedules every tick until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context, tick time.Duration) {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.RunDue(ctx); err != nil {
+				log.Printf("scheduler: run due schedules: %v", err)
+			}
+		}
+	}
+}
+
+// RunDue generates every invoice still owed by an active schedule (its
+// NextRunAt, and any earlier period it fell behind on), advancing each
+// schedule as it goes. It returns how many invoices were generated.
+func (s *Scheduler) RunDue(ctx context.Context) (int, error) {
+	var schedules []models.ContractSchedule
+	now := time.Now()
+	if err := s.db.WithContext(ctx).Preload("Contract").
+		Where("status = ? AND next_run_at <= ?", "active", now).
+		Find(&schedules).Error; err != nil {
+		return 0, fmt.Errorf("load due schedules: %w", err)
+	}
+
+	generated := 0
+	for i := range schedules {
+		n, err := s.runOne(ctx, &schedules[i], now)
+		generated += n
+		if err != nil {
+			log.Printf("scheduler: schedule %d: %v", schedules[i].ID, err)
+		}
+	}
+	return generated, nil
+}
+
+// Backfill catches one schedule up to now, generating every period it
+// missed (e.g. while the scheduler worker was down), and returns how many
+// invoices were generated.
+func (s *Scheduler) Backfill(ctx context.Context, scheduleID uint) (int, error) {
+	var schedule models.ContractSchedule
+	if err := s.db.WithContext(ctx).Preload("Contract").First(&schedule, scheduleID).Error; err != nil {
+		return 0, fmt.Errorf("schedule not found: %w", err)
+	}
+	if schedule.Status != "active" {
+		return 0, fmt.Errorf("schedule %d is not active", scheduleID)
+	}
+
+	return s.runOne(ctx, &schedule, time.Now())
+}
+
+// CancelSchedule stops future generation for a schedule, recording why.
+func (s *Scheduler) CancelSchedule(scheduleID uint, reason string) error {
+	return s.db.Model(&models.ContractSchedule{}).Where("id = ?", scheduleID).
+		Updates(map[string]interface{}{"status": "cancelled", "cancelled_reason": reason}).Error
+}
+
+// PreviewUpcoming returns up to n future period start dates for a
+// schedule, stopping at the contract's agreement end, without writing
+// anything.
+func (s *Scheduler) PreviewUpcoming(scheduleID uint, n int) ([]time.Time, error) {
+	var schedule models.ContractSchedule
+	if err := s.db.Preload("Contract").First(&schedule, scheduleID).Error; err != nil {
+		return nil, fmt.Errorf("schedule not found: %w", err)
+	}
+
+	dates := make([]time.Time, 0, n)
+	next := schedule.NextRunAt
+	for i := 0; i < n; i++ {
+		if pastAgreementEnd(schedule.Contract, next) {
+			break
+		}
+		dates = append(dates, next)
+		if schedule.Cadence == "upfront" {
+			break
+		}
+		next = nextPeriod(next, schedule.Cadence)
+	}
+	return dates, nil
+}
+
+// runOne generates every period still due for one schedule and advances
+// it, so both RunDue's per-tick pass and an on-demand Backfill share the
+// same catch-up logic.
+func (s *Scheduler) runOne(ctx context.Context, schedule *models.ContractSchedule, now time.Time) (int, error) {
+	generated := 0
+	for !schedule.NextRunAt.After(now) {
+		if pastAgreementEnd(schedule.Contract, schedule.NextRunAt) {
+			return generated, s.db.WithContext(ctx).Model(schedule).Update("status", "completed").Error
+		}
+
+		periodStart := schedule.NextRunAt
+		if _, err := s.generateInvoice(ctx, schedule.Contract, periodStart); err != nil {
+			return generated, err
+		}
+		generated++
+
+		next := nextPeriod(periodStart, schedule.Cadence)
+		updates := map[string]interface{}{"next_run_at": next, "last_run_at": periodStart}
+		if schedule.Cadence == "upfront" {
+			updates["status"] = "completed"
+		}
+		if err := s.db.WithContext(ctx).Model(schedule).Updates(updates).Error; err != nil {
+			return generated, err
+		}
+
+		schedule.NextRunAt = next
+		schedule.LastRunAt = &periodStart
+		if schedule.Cadence == "upfront" {
+			schedule.Status = "completed"
+			return generated, nil
+		}
+	}
+	return generated, nil
+}
+
+// generateInvoice creates the invoice for one period and calculates its
+// commission, tolerating a duplicate-period retry (e.g. a crashed RunDue
+// re-processing the same schedule) via the unique (contract_id,
+// period_start) index instead of erroring.
+func (s *Scheduler) generateInvoice(ctx context.Context, contract models.Contract, periodStart time.Time) (*models.Invoice, error) {
+	invoice := models.Invoice{
+		ContractID:  contract.ID,
+		Amount:      contract.ContractValue,
+		InvoiceDate: periodStart,
+		RevenueType: "recurring",
+		PeriodStart: &periodStart,
+		CreatedBy:   contract.CreatedBy,
+	}
+
+	if err := s.db.WithContext(ctx).Create(&invoice).Error; err != nil {
+		if isDuplicatePeriod(err) {
+			var existing models.Invoice
+			if findErr := s.db.WithContext(ctx).
+				Where("contract_id = ? AND period_start = ?", contract.ID, periodStart).
+				First(&existing).Error; findErr != nil {
+				return nil, findErr
+			}
+			return &existing, nil
+		}
+		return nil, fmt.Errorf("create invoice for period %s: %w", periodStart.Format("2006-01-02"), err)
+	}
+
+	if _, err := s.commission.CalculateCommission(invoice); err != nil {
+		return nil, fmt.Errorf("calculate commission for period %s: %w", periodStart.Format("2006-01-02"), err)
+	}
+
+	return &invoice, nil
+}
+
+// pastAgreementEnd reports whether at is after the contract's agreement
+// end, treating NoEndingDate or a nil end date as never ending.
+func pastAgreementEnd(contract models.Contract, at time.Time) bool {
+	if contract.NoEndingDate || contract.AgreementEndDate == nil {
+		return false
+	}
+	return at.After(*contract.AgreementEndDate)
+}
+
+func nextPeriod(from time.Time, cadence string) time.Time {
+	switch cadence {
+	case "monthly":
+		return from.AddDate(0, 1, 0)
+	case "quarterly":
+		return from.AddDate(0, 3, 0)
+	case "annual":
+		return from.AddDate(1, 0, 0)
+	default: // upfront: one-time, no further period
+		return from
+	}
+}
+
+func isDuplicatePeriod(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23505"
+	}
+	return false
+}
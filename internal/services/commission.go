@@ -1,22 +1,153 @@
 package services
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"sync"
 	"time"
 
+	"github.com/lib/pq"
 	"gorm.io/gorm"
+	"hubpay/internal/ledger"
 	"hubpay/internal/models"
+	"hubpay/internal/rates"
 )
 
+// configChangeChannel is the Postgres NOTIFY channel other HubPay replicas
+// publish to after a commission config mutation, so every replica's
+// in-memory cache stays consistent without polling the database.
+const configChangeChannel = "hubpay_config_changed"
+
 type CommissionService struct {
-	db *gorm.DB
+	db     *gorm.DB
+	ledger *ledger.Ledger
+	rates  *rates.Service
+
+	cacheMu     sync.RWMutex
+	configCache map[uint]*models.CommissionConfig
+	version     uint64
+}
+
+func NewCommissionService(db *gorm.DB, lg *ledger.Ledger, rs *rates.Service) *CommissionService {
+	return &CommissionService{db: db, ledger: lg, rates: rs, configCache: make(map[uint]*models.CommissionConfig)}
+}
+
+// ConfigReloadDiff summarizes what changed between two cache generations,
+// for logging and for the staleness-diagnosing admin endpoints.
+type ConfigReloadDiff struct {
+	Version int    `json:"version"`
+	Added   []uint `json:"added"`
+	Removed []uint `json:"removed"`
+	Changed []uint `json:"changed"`
+}
+
+// Version reports the current config cache generation.
+func (s *CommissionService) Version() uint64 {
+	s.cacheMu.RLock()
+	defer s.cacheMu.RUnlock()
+	return s.version
+}
+
+// ReloadConfigs re-queries every active AE commission assignment and
+// atomically swaps the in-memory cache, modeled on smallstep authority's
+// reload-then-swap pattern so calculations never observe a half-updated map.
+func (s *CommissionService) ReloadConfigs(ctx context.Context) (*ConfigReloadDiff, error) {
+	var assignments []models.AECommissionAssignment
+	now := time.Now()
+	if err := s.db.WithContext(ctx).Preload("CommissionConfig").
+		Where("start_date <= ? AND (end_date IS NULL OR end_date > ?)", now, now).
+		Find(&assignments).Error; err != nil {
+		return nil, fmt.Errorf("reload commission configs: %w", err)
+	}
+
+	next := make(map[uint]*models.CommissionConfig, len(assignments))
+	for i := range assignments {
+		cfg := assignments[i].CommissionConfig
+		next[assignments[i].AEID] = &cfg
+	}
+
+	s.cacheMu.Lock()
+	diff := diffConfigs(s.configCache, next)
+	s.configCache = next
+	s.version++
+	diff.Version = int(s.version)
+	s.cacheMu.Unlock()
+
+	log.Printf("commission config reload: version=%d added=%d removed=%d changed=%d",
+		diff.Version, len(diff.Added), len(diff.Removed), len(diff.Changed))
+
+	return diff, nil
+}
+
+// ListenForConfigChanges subscribes to the Postgres NOTIFY channel other
+// replicas publish to after a config mutation and reloads the cache on
+// every notification, keeping multi-replica deployments consistent. It
+// runs until ctx is cancelled.
+func (s *CommissionService) ListenForConfigChanges(ctx context.Context, databaseURL string) error {
+	listener := pq.NewListener(databaseURL, 10*time.Second, time.Minute, func(_ pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("commission config listener: %v", err)
+		}
+	})
+	if err := listener.Listen(configChangeChannel); err != nil {
+		return fmt.Errorf("listen on %s: %w", configChangeChannel, err)
+	}
+
+	go func() {
+		defer listener.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-listener.Notify:
+				if _, err := s.ReloadConfigs(ctx); err != nil {
+					log.Printf("commission config reload after notify: %v", err)
+				}
+			case <-time.After(90 * time.Second):
+				_ = listener.Ping()
+			}
+		}
+	}()
+
+	return nil
+}
+
+// NotifyConfigChanged publishes to configChangeChannel so every replica's
+// listener reloads its cache. Call this after committing a commission
+// config mutation.
+func (s *CommissionService) NotifyConfigChanged() error {
+	return s.db.Exec("NOTIFY " + configChangeChannel).Error
 }
 
-func NewCommissionService(db *gorm.DB) *CommissionService {
-	return &CommissionService{db: db}
+func diffConfigs(prev, next map[uint]*models.CommissionConfig) *ConfigReloadDiff {
+	diff := &ConfigReloadDiff{}
+
+	for aeID, cfg := range next {
+		old, existed := prev[aeID]
+		if !existed {
+			diff.Added = append(diff.Added, aeID)
+			continue
+		}
+		if old.ID != cfg.ID || old.BaseRate != cfg.BaseRate || old.OTECap != cfg.OTECap {
+			diff.Changed = append(diff.Changed, aeID)
+		}
+	}
+	for aeID := range prev {
+		if _, stillPresent := next[aeID]; !stillPresent {
+			diff.Removed = append(diff.Removed, aeID)
+		}
+	}
+
+	return diff
 }
 
+// CalculateCommission derives a commission from an invoice and persists it
+// along with its opening journal entry (debiting accrued, crediting the OTE
+// reserve) in a single transaction, so a commission never exists without
+// the ledger rows that explain it.
 func (s *CommissionService) CalculateCommission(invoice models.Invoice) (*models.Commission, error) {
 	// Get the contract
 	var contract models.Contract
@@ -40,35 +171,388 @@ func (s *CommissionService) CalculateCommission(invoice models.Invoice) (*models
 
 	totalCommission := baseCommission + pilotBonus + multiYearBonus + upfrontBonus
 
-	// Apply OTE cap if necessary
+	reportingAmount, err := s.rates.Convert(context.Background(), totalCommission, contract.Currency, rates.ReportingCurrency, invoice.InvoiceDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert commission to reporting currency: %w", err)
+	}
+
+	// Apply OTE cap if necessary, comparing the AE's year-to-date bookings
+	// and this commission in the reporting currency so deals closed in
+	// different currencies are compared on a single scale.
 	oteCapApplied := false
 	if config.OTECap > 0 {
-		capped, err := s.applyOTECap(contract.AEID, totalCommission, config)
+		capped, err := s.applyOTECap(contract.AEID, 0, reportingAmount, config, invoice.InvoiceDate)
 		if err != nil {
 			return nil, fmt.Errorf("failed to apply OTE cap: %w", err)
 		}
 		if capped {
 			oteCapApplied = true
 			totalCommission = int64(float64(totalCommission) * config.DeceleratorRate)
+			reportingAmount = int64(float64(reportingAmount) * config.DeceleratorRate)
 		}
 	}
 
 	commission := &models.Commission{
-		InvoiceID:       invoice.ID,
-		AEID:            contract.AEID,
-		BaseCommission:  baseCommission,
-		PilotBonus:      pilotBonus,
-		MultiYearBonus:  multiYearBonus,
-		UpfrontBonus:    upfrontBonus,
-		TotalCommission: totalCommission,
-		Status:          "pending",
-		OTECapApplied:   oteCapApplied,
+		InvoiceID:          invoice.ID,
+		AEID:               contract.AEID,
+		CommissionConfigID: configIDPointer(config),
+		ConfigVersion:      config.Version,
+		BaseCommission:     baseCommission,
+		PilotBonus:         pilotBonus,
+		MultiYearBonus:     multiYearBonus,
+		UpfrontBonus:       upfrontBonus,
+		TotalCommission:    totalCommission,
+		Currency:           contract.Currency,
+		ReportingAmount:    reportingAmount,
+		ReportingCurrency:  rates.ReportingCurrency,
+		Status:             "pending",
+		OTECapApplied:      oteCapApplied,
 	}
 
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(commission).Error; err != nil {
+			return err
+		}
+		return s.ledger.Post(tx, ledger.Entry{
+			CommissionID:  commission.ID,
+			AEID:          commission.AEID,
+			EventType:     ledger.EventCalculated,
+			DebitAccount:  ledger.AccountAccrued,
+			CreditAccount: ledger.AccountOTEReserve,
+			AmountCents:   totalCommission,
+			CreatedBy:     contract.CreatedBy,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to persist commission: %w", err)
+	}
+
+	return commission, nil
+}
+
+// UpdateStatus transitions a commission to a new status, writing the
+// ledger entries that explain the transition in the same transaction. It
+// refuses to move a commission already in a terminal `paid` state.
+func (s *CommissionService) UpdateStatus(commissionID uint, newStatus string, actorID uint, rejectionReason string) (*models.Commission, error) {
+	var commission *models.Commission
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		updated, err := s.UpdateStatusTx(tx, commissionID, newStatus, actorID, rejectionReason)
+		commission = updated
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 	return commission, nil
 }
 
+// UpdateStatusTx is UpdateStatus run inside a caller-owned transaction, so
+// a multi-commission operation like paying out a Payroll batch can move
+// every member commission and its ledger entries as one atomic unit
+// instead of one transaction per commission.
+func (s *CommissionService) UpdateStatusTx(tx *gorm.DB, commissionID uint, newStatus string, actorID uint, rejectionReason string) (*models.Commission, error) {
+	var commission models.Commission
+	if err := tx.Preload("Invoice").First(&commission, commissionID).Error; err != nil {
+		return nil, fmt.Errorf("commission not found: %w", err)
+	}
+
+	if commission.Status == "paid" {
+		return nil, fmt.Errorf("commission %d is already paid and cannot be modified", commissionID)
+	}
+
+	debit, credit, eventType, err := transitionAccounts(commission.Status, newStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	before := snapshotCommission(commission)
+
+	updates := map[string]interface{}{"status": newStatus}
+	switch newStatus {
+	case "approved", "paid":
+		now := time.Now()
+		updates["approved_by"] = actorID
+		updates["approved_at"] = now
+	case "rejected":
+		updates["rejection_reason"] = rejectionReason
+	}
+
+	// Re-verify the OTE cap against the config pinned at calculation time
+	// (CommissionConfigID/ConfigVersion), not whatever's currently active
+	// for the AE — config changes between calculation and (re-)approval
+	// shouldn't silently change what cap a commission is judged against.
+	if newStatus == "approved" && !commission.OTECapApplied {
+		config, err := s.configForApproval(&commission)
+		if err != nil {
+			return nil, fmt.Errorf("resolve pinned commission config: %w", err)
+		}
+		if config.OTECap > 0 {
+			capped, err := s.applyOTECap(commission.AEID, commission.ID, commission.ReportingAmount, config, commission.Invoice.InvoiceDate)
+			if err != nil {
+				return nil, fmt.Errorf("failed to apply OTE cap: %w", err)
+			}
+			if capped {
+				commission.OTECapApplied = true
+				commission.TotalCommission = int64(float64(commission.TotalCommission) * config.DeceleratorRate)
+				commission.ReportingAmount = int64(float64(commission.ReportingAmount) * config.DeceleratorRate)
+				updates["ote_cap_applied"] = true
+				updates["total_commission"] = commission.TotalCommission
+				updates["reporting_amount"] = commission.ReportingAmount
+			}
+		}
+	}
+
+	if err := tx.Model(&commission).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+
+	if err := s.ledger.Post(tx, ledger.Entry{
+		CommissionID:  commission.ID,
+		AEID:          commission.AEID,
+		EventType:     eventType,
+		DebitAccount:  debit,
+		CreditAccount: credit,
+		AmountCents:   commission.TotalCommission,
+		CreatedBy:     actorID,
+	}); err != nil {
+		return nil, err
+	}
+
+	commission.Status = newStatus
+
+	after := snapshotCommission(commission)
+	if err := writeAudit(tx, commission.ID, "status_change", actorID, before, after, rejectionReason); err != nil {
+		return nil, err
+	}
+
+	return &commission, nil
+}
+
+// commissionSnapshot is the subset of a Commission's fields that change
+// across a status transition or recompute, captured before/after on a
+// CommissionAudit entry.
+type commissionSnapshot struct {
+	Status             string `json:"status"`
+	CommissionConfigID *uint  `json:"commissionConfigId,omitempty"`
+	ConfigVersion      int    `json:"configVersion"`
+	BaseCommission     int64  `json:"baseCommission"`
+	PilotBonus         int64  `json:"pilotBonus"`
+	MultiYearBonus     int64  `json:"multiYearBonus"`
+	UpfrontBonus       int64  `json:"upfrontBonus"`
+	TotalCommission    int64  `json:"totalCommission"`
+	ReportingAmount    int64  `json:"reportingAmount"`
+	OTECapApplied      bool   `json:"oteCapApplied"`
+}
+
+func snapshotCommission(c models.Commission) commissionSnapshot {
+	return commissionSnapshot{
+		Status:             c.Status,
+		CommissionConfigID: c.CommissionConfigID,
+		ConfigVersion:      c.ConfigVersion,
+		BaseCommission:     c.BaseCommission,
+		PilotBonus:         c.PilotBonus,
+		MultiYearBonus:     c.MultiYearBonus,
+		UpfrontBonus:       c.UpfrontBonus,
+		TotalCommission:    c.TotalCommission,
+		ReportingAmount:    c.ReportingAmount,
+		OTECapApplied:      c.OTECapApplied,
+	}
+}
+
+// writeAudit appends a CommissionAudit row recording a before/after
+// snapshot of a commission change, in the same transaction as the change
+// itself so the audit trail can never drift from what actually happened.
+func writeAudit(tx *gorm.DB, commissionID uint, action string, actorID uint, before, after interface{}, reason string) error {
+	beforeJSON, err := json.Marshal(before)
+	if err != nil {
+		return fmt.Errorf("marshal audit before state: %w", err)
+	}
+	afterJSON, err := json.Marshal(after)
+	if err != nil {
+		return fmt.Errorf("marshal audit after state: %w", err)
+	}
+
+	return tx.Create(&models.CommissionAudit{
+		CommissionID: commissionID,
+		Action:       action,
+		ActorID:      actorID,
+		BeforeJSON:   string(beforeJSON),
+		AfterJSON:    string(afterJSON),
+		Reason:       reason,
+	}).Error
+}
+
+// Recompute re-derives a commission's Base/Pilot/MultiYear/Upfront bonuses
+// and OTE cap application from the AE's current active commission config,
+// writing the delta to the ledger and a CommissionAudit entry in the same
+// transaction. It refuses to touch a commission already `paid`.
+func (s *CommissionService) Recompute(commissionID uint, actorID uint, reason string) (*models.Commission, error) {
+	var commission models.Commission
+	if err := s.db.Preload("Invoice.Contract").First(&commission, commissionID).Error; err != nil {
+		return nil, fmt.Errorf("commission not found: %w", err)
+	}
+	if commission.Status == "paid" {
+		return nil, fmt.Errorf("commission %d is already paid and cannot be recomputed", commissionID)
+	}
+
+	contract := commission.Invoice.Contract
+	config, err := s.getActiveCommissionConfigForAE(commission.AEID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commission config: %w", err)
+	}
+
+	baseCommission := int64(float64(commission.Invoice.Amount) * config.BaseRate)
+	pilotBonus := s.calculatePilotBonus(contract, commission.Invoice.Amount, config)
+	multiYearBonus := s.calculateMultiYearBonus(contract, config)
+	upfrontBonus := s.calculateUpfrontBonus(contract, config)
+	totalCommission := baseCommission + pilotBonus + multiYearBonus + upfrontBonus
+
+	reportingAmount, err := s.rates.Convert(context.Background(), totalCommission, contract.Currency, rates.ReportingCurrency, commission.Invoice.InvoiceDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert commission to reporting currency: %w", err)
+	}
+
+	oteCapApplied := false
+	if config.OTECap > 0 {
+		capped, err := s.applyOTECap(commission.AEID, commission.ID, reportingAmount, config, commission.Invoice.InvoiceDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply OTE cap: %w", err)
+		}
+		if capped {
+			oteCapApplied = true
+			totalCommission = int64(float64(totalCommission) * config.DeceleratorRate)
+			reportingAmount = int64(float64(reportingAmount) * config.DeceleratorRate)
+		}
+	}
+
+	before := snapshotCommission(commission)
+	delta := totalCommission - commission.TotalCommission
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{
+			"commission_config_id": configIDPointer(config),
+			"config_version":       config.Version,
+			"base_commission":      baseCommission,
+			"pilot_bonus":          pilotBonus,
+			"multi_year_bonus":     multiYearBonus,
+			"upfront_bonus":        upfrontBonus,
+			"total_commission":     totalCommission,
+			"reporting_amount":     reportingAmount,
+			"ote_cap_applied":      oteCapApplied,
+		}
+		if err := tx.Model(&commission).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		if delta != 0 {
+			debit, credit, amount := ledger.AccountAccrued, ledger.AccountOTEReserve, delta
+			if delta < 0 {
+				debit, credit, amount = ledger.AccountOTEReserve, ledger.AccountAccrued, -delta
+			}
+			if err := s.ledger.Post(tx, ledger.Entry{
+				CommissionID:  commission.ID,
+				AEID:          commission.AEID,
+				EventType:     ledger.EventRecomputed,
+				DebitAccount:  debit,
+				CreditAccount: credit,
+				AmountCents:   amount,
+				CreatedBy:     actorID,
+			}); err != nil {
+				return err
+			}
+		}
+
+		commission.CommissionConfigID = configIDPointer(config)
+		commission.ConfigVersion = config.Version
+		commission.BaseCommission = baseCommission
+		commission.PilotBonus = pilotBonus
+		commission.MultiYearBonus = multiYearBonus
+		commission.UpfrontBonus = upfrontBonus
+		commission.TotalCommission = totalCommission
+		commission.ReportingAmount = reportingAmount
+		commission.OTECapApplied = oteCapApplied
+
+		return writeAudit(tx, commission.ID, "recompute", actorID, before, snapshotCommission(commission), reason)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("recompute commission %d: %w", commissionID, err)
+	}
+
+	return &commission, nil
+}
+
+// RecomputeRange recomputes every non-paid commission in [from, to),
+// optionally restricted to one AE, continuing past individual failures
+// (e.g. a commission that's already paid) so one bad row doesn't block
+// the rest of the batch.
+func (s *CommissionService) RecomputeRange(aeID *uint, from, to time.Time, actorID uint, reason string) ([]*models.Commission, error) {
+	query := s.db.Model(&models.Commission{}).
+		Where("created_at >= ? AND created_at < ? AND status != ?", from, to, "paid")
+	if aeID != nil {
+		query = query.Where("ae_id = ?", *aeID)
+	}
+
+	var ids []uint
+	if err := query.Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("load commissions to recompute: %w", err)
+	}
+
+	recomputed := make([]*models.Commission, 0, len(ids))
+	for _, id := range ids {
+		commission, err := s.Recompute(id, actorID, reason)
+		if err != nil {
+			log.Printf("recompute commission %d: %v", id, err)
+			continue
+		}
+		recomputed = append(recomputed, commission)
+	}
+
+	return recomputed, nil
+}
+
+// transitionAccounts maps a commission status change onto the ledger
+// accounts it moves an amount between.
+func transitionAccounts(from, to string) (debit, credit, eventType string, err error) {
+	if from == to {
+		return "", "", "", fmt.Errorf("unsupported commission status transition %q -> %q", from, to)
+	}
+
+	switch to {
+	case "approved":
+		if from != "pending" {
+			return "", "", "", fmt.Errorf("unsupported commission status transition %q -> %q", from, to)
+		}
+		return ledger.AccountApproved, ledger.AccountAccrued, ledger.EventApproved, nil
+	case "rejected":
+		if from == "approved" {
+			return ledger.AccountClawback, ledger.AccountApproved, ledger.EventRejected, nil
+		}
+		if from != "pending" {
+			return "", "", "", fmt.Errorf("unsupported commission status transition %q -> %q", from, to)
+		}
+		return ledger.AccountClawback, ledger.AccountAccrued, ledger.EventRejected, nil
+	case "paid":
+		if from != "approved" {
+			return "", "", "", fmt.Errorf("unsupported commission status transition %q -> %q", from, to)
+		}
+		return ledger.AccountPaid, ledger.AccountApproved, ledger.EventPaid, nil
+	default:
+		return "", "", "", fmt.Errorf("unsupported commission status transition %q -> %q", from, to)
+	}
+}
+
+// getActiveCommissionConfigForAE reads the AE's config from the in-memory
+// cache, falling back to the join this used to run on every call only when
+// the cache hasn't been populated for that AE yet (e.g. before the first
+// ReloadConfigs, or in tests that construct the service directly).
 func (s *CommissionService) getActiveCommissionConfigForAE(aeID uint) (*models.CommissionConfig, error) {
+	s.cacheMu.RLock()
+	cached, ok := s.configCache[aeID]
+	s.cacheMu.RUnlock()
+	if ok {
+		return cached, nil
+	}
+
 	var assignment models.AECommissionAssignment
 	query := s.db.Preload("CommissionConfig").
 		Where("ae_id = ? AND start_date <= ?", aeID, time.Now()).
@@ -86,15 +570,111 @@ func (s *CommissionService) getActiveCommissionConfigForAE(aeID uint) (*models.C
 	return &assignment.CommissionConfig, nil
 }
 
+// ConfigByID fetches one exact commission config version by its row ID, for
+// contexts that need the precise config a commission was calculated under
+// (audit, re-approval, simulation) rather than whatever is currently active
+// for the AE.
+func (s *CommissionService) ConfigByID(id uint) (*models.CommissionConfig, error) {
+	var config models.CommissionConfig
+	if err := s.db.First(&config, id).Error; err != nil {
+		return nil, fmt.Errorf("commission config %d not found: %w", id, err)
+	}
+	return &config, nil
+}
+
+// configForApproval resolves the config a commission was actually
+// calculated under (its pinned CommissionConfigID), falling back to the
+// unpersisted default when the commission predates config assignment.
+func (s *CommissionService) configForApproval(commission *models.Commission) (*models.CommissionConfig, error) {
+	if commission.CommissionConfigID == nil {
+		return s.getDefaultCommissionConfig()
+	}
+	return s.ConfigByID(*commission.CommissionConfigID)
+}
+
+// configIDPointer returns the persisted config's ID, or nil if the
+// commission fell back to the unpersisted default configuration.
+func configIDPointer(config *models.CommissionConfig) *uint {
+	if config.ID == 0 {
+		return nil
+	}
+	id := config.ID
+	return &id
+}
+
+// SimulatedCommission is one invoice's commission as currently booked
+// versus what it would be under a different config.
+type SimulatedCommission struct {
+	InvoiceID      uint  `json:"invoiceId"`
+	CurrentTotal   int64 `json:"currentTotal"`
+	SimulatedTotal int64 `json:"simulatedTotal"`
+	Delta          int64 `json:"delta"`
+}
+
+// SimulationResult is the per-invoice breakdown plus aggregates returned by
+// SimulateConfig.
+type SimulationResult struct {
+	Items          []SimulatedCommission `json:"items"`
+	TotalCurrent   int64                 `json:"totalCurrent"`
+	TotalSimulated int64                 `json:"totalSimulated"`
+	TotalDelta     int64                 `json:"totalDelta"`
+}
+
+// SimulateConfig recomputes every commission booked for the given AEs
+// within [start, end) against config, without writing anything, so an
+// admin can preview a rate change's impact before calling
+// AssignCommissionConfig. The OTE cap is intentionally not applied here: it
+// depends on an AE's actual year-to-date bookings, which the simulation
+// would otherwise conflate with history unrelated to the config under
+// test.
+func (s *CommissionService) SimulateConfig(aeIDs []uint, start, end time.Time, config *models.CommissionConfig) (*SimulationResult, error) {
+	var commissions []models.Commission
+	query := s.db.Preload("Invoice.Contract").Where("created_at >= ? AND created_at < ?", start, end)
+	if len(aeIDs) > 0 {
+		query = query.Where("ae_id IN ?", aeIDs)
+	}
+	if err := query.Find(&commissions).Error; err != nil {
+		return nil, fmt.Errorf("load commissions: %w", err)
+	}
+
+	result := &SimulationResult{}
+	for _, commission := range commissions {
+		simulatedTotal := s.computeTotal(commission.Invoice.Contract, commission.Invoice.Amount, config)
+
+		item := SimulatedCommission{
+			InvoiceID:      commission.InvoiceID,
+			CurrentTotal:   commission.TotalCommission,
+			SimulatedTotal: simulatedTotal,
+			Delta:          simulatedTotal - commission.TotalCommission,
+		}
+		result.Items = append(result.Items, item)
+		result.TotalCurrent += item.CurrentTotal
+		result.TotalSimulated += item.SimulatedTotal
+		result.TotalDelta += item.Delta
+	}
+
+	return result, nil
+}
+
+// computeTotal applies config's rates to one invoice/contract pair, the
+// same arithmetic CalculateCommission uses for base commission and bonuses.
+func (s *CommissionService) computeTotal(contract models.Contract, invoiceAmount int64, config *models.CommissionConfig) int64 {
+	base := int64(float64(invoiceAmount) * config.BaseRate)
+	pilot := s.calculatePilotBonus(contract, invoiceAmount, config)
+	multiYear := s.calculateMultiYearBonus(contract, config)
+	upfront := s.calculateUpfrontBonus(contract, config)
+	return base + pilot + multiYear + upfront
+}
+
 func (s *CommissionService) getDefaultCommissionConfig() (*models.CommissionConfig, error) {
 	return &models.CommissionConfig{
 		Name:               "Default Configuration",
-		BaseRate:           0.10, // 10%
-		PilotBonusRate:     0.02, // 2%
-		MultiYearBonusRate: 0.01, // 1%
-		UpfrontBonusRate:   0.01, // 1%
+		BaseRate:           0.10,      // 10%
+		PilotBonusRate:     0.02,      // 2%
+		MultiYearBonusRate: 0.01,      // 1%
+		UpfrontBonusRate:   0.01,      // 1%
 		OTECap:             100000000, // $1M in cents
-		DeceleratorRate:    0.90, // 90%
+		DeceleratorRate:    0.90,      // 90%
 	}, nil
 }
 
@@ -119,21 +699,43 @@ func (s *CommissionService) calculateUpfrontBonus(contract models.Contract, conf
 	return int64(float64(contract.ACV) * config.UpfrontBonusRate)
 }
 
-func (s *CommissionService) applyOTECap(aeID uint, newCommission int64, config *models.CommissionConfig) (bool, error) {
+// applyOTECap reports whether adding newCommissionReporting (already
+// converted to rates.ReportingCurrency) to the AE's year-to-date reporting
+// totals would exceed config.OTECap. OTECap is denominated in
+// config.Currency, which may differ from the reporting currency, so it's
+// converted once using the same asOf date before comparing.
+// excludeCommissionID, when non-zero, leaves that commission's own row out
+// of the year-to-date sum. Callers re-checking a commission that already
+// has a persisted (and possibly stale) reporting_amount — e.g. Recompute,
+// which passes the freshly-computed amount separately as
+// newCommissionReporting — must exclude it here or its old amount gets
+// counted once from the DB and again via newCommissionReporting.
+func (s *CommissionService) applyOTECap(aeID, excludeCommissionID uint, newCommissionReporting int64, config *models.CommissionConfig, asOf time.Time) (bool, error) {
 	if config.OTECap == 0 {
 		return false, nil
 	}
 
+	capCurrency := config.Currency
+	if capCurrency == "" {
+		capCurrency = rates.ReportingCurrency
+	}
+	capInReporting, err := s.rates.Convert(context.Background(), config.OTECap, capCurrency, rates.ReportingCurrency, asOf)
+	if err != nil {
+		return false, fmt.Errorf("convert OTE cap to reporting currency: %w", err)
+	}
+
 	// Get year-to-date commissions
 	startOfYear := time.Date(time.Now().Year(), 1, 1, 0, 0, 0, 0, time.UTC)
-	
-	var totalCommissions int64
-	if err := s.db.Model(&models.Commission{}).
-		Where("ae_id = ? AND created_at >= ? AND status IN ?", aeID, startOfYear, []string{"approved", "paid"}).
-		Select("COALESCE(SUM(total_commission), 0)").
-		Scan(&totalCommissions).Error; err != nil {
+
+	var totalReporting int64
+	query := s.db.Model(&models.Commission{}).
+		Where("ae_id = ? AND created_at >= ? AND status IN ?", aeID, startOfYear, []string{"approved", "paid"})
+	if excludeCommissionID != 0 {
+		query = query.Where("id != ?", excludeCommissionID)
+	}
+	if err := query.Select("COALESCE(SUM(reporting_amount), 0)").Scan(&totalReporting).Error; err != nil {
 		return false, err
 	}
 
-	return totalCommissions+newCommission > config.OTECap, nil
-}
\ No newline at end of file
+	return totalReporting+newCommissionReporting > capInReporting, nil
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+
+	"hubpay/internal/models"
+)
+
+func TestSnapshotCommissionCapturesMutableFields(t *testing.T) {
+	configID := uint(7)
+	commission := models.Commission{
+		Status:             "pending",
+		CommissionConfigID: &configID,
+		ConfigVersion:      2,
+		BaseCommission:     1000,
+		PilotBonus:         100,
+		MultiYearBonus:     200,
+		UpfrontBonus:       50,
+		TotalCommission:    1350,
+		ReportingAmount:    1350,
+		OTECapApplied:      false,
+	}
+
+	before := snapshotCommission(commission)
+
+	// Simulate what UpdateStatusTx/Recompute do to a commission in place:
+	// flip status and, on approval, apply the OTE cap.
+	commission.Status = "approved"
+	commission.OTECapApplied = true
+	commission.TotalCommission = 1200
+	commission.ReportingAmount = 1200
+
+	after := snapshotCommission(commission)
+
+	if before.Status != "pending" || after.Status != "approved" {
+		t.Fatalf("snapshotCommission did not capture status transition: before=%q after=%q", before.Status, after.Status)
+	}
+	if before.OTECapApplied != false || after.OTECapApplied != true {
+		t.Fatalf("snapshotCommission did not capture OTECapApplied change: before=%v after=%v", before.OTECapApplied, after.OTECapApplied)
+	}
+	if before.TotalCommission != 1350 || after.TotalCommission != 1200 {
+		t.Fatalf("snapshotCommission did not capture TotalCommission change: before=%d after=%d", before.TotalCommission, after.TotalCommission)
+	}
+	if before.ReportingAmount != 1350 || after.ReportingAmount != 1200 {
+		t.Fatalf("snapshotCommission did not capture ReportingAmount change: before=%d after=%d", before.ReportingAmount, after.ReportingAmount)
+	}
+	if before.ConfigVersion != after.ConfigVersion || before.ConfigVersion != 2 {
+		t.Fatalf("snapshotCommission changed ConfigVersion unexpectedly: before=%d after=%d", before.ConfigVersion, after.ConfigVersion)
+	}
+	if before.CommissionConfigID == nil || *before.CommissionConfigID != configID {
+		t.Fatalf("snapshotCommission did not capture CommissionConfigID: got %v", before.CommissionConfigID)
+	}
+}
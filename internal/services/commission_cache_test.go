@@ -0,0 +1,30 @@
+package services
+
+import (
+	"testing"
+
+	"hubpay/internal/models"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	prev := map[uint]*models.CommissionConfig{
+		1: {ID: 10, BaseRate: 0.1},
+		2: {ID: 20, BaseRate: 0.2},
+	}
+	next := map[uint]*models.CommissionConfig{
+		2: {ID: 20, BaseRate: 0.25}, // changed rate
+		3: {ID: 30, BaseRate: 0.3},  // newly assigned
+	}
+
+	diff := diffConfigs(prev, next)
+
+	if len(diff.Added) != 1 || diff.Added[0] != 3 {
+		t.Fatalf("Added = %v, want [3]", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0] != 1 {
+		t.Fatalf("Removed = %v, want [1]", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0] != 2 {
+		t.Fatalf("Changed = %v, want [2]", diff.Changed)
+	}
+}
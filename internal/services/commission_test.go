@@ -0,0 +1,46 @@
+package services
+
+import (
+	"testing"
+
+	"hubpay/internal/ledger"
+)
+
+func TestTransitionAccounts(t *testing.T) {
+	tests := []struct {
+		name       string
+		from, to   string
+		wantDebit  string
+		wantCredit string
+		wantEvent  string
+		wantErr    bool
+	}{
+		{"pending to approved", "pending", "approved", ledger.AccountApproved, ledger.AccountAccrued, ledger.EventApproved, false},
+		{"pending to rejected", "pending", "rejected", ledger.AccountClawback, ledger.AccountAccrued, ledger.EventRejected, false},
+		{"approved to rejected claws back from approved", "approved", "rejected", ledger.AccountClawback, ledger.AccountApproved, ledger.EventRejected, false},
+		{"approved to paid", "approved", "paid", ledger.AccountPaid, ledger.AccountApproved, ledger.EventPaid, false},
+		{"unsupported target status", "pending", "pending", "", "", "", true},
+		{"pending cannot jump straight to paid", "pending", "paid", "", "", "", true},
+		{"rejected cannot jump straight to paid", "rejected", "paid", "", "", "", true},
+		{"paid cannot re-enter approved", "paid", "approved", "", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debit, credit, event, err := transitionAccounts(tt.from, tt.to)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("transitionAccounts(%q, %q) error = nil, want error", tt.from, tt.to)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("transitionAccounts(%q, %q) error = %v", tt.from, tt.to, err)
+			}
+			if debit != tt.wantDebit || credit != tt.wantCredit || event != tt.wantEvent {
+				t.Fatalf("transitionAccounts(%q, %q) = (%q, %q, %q), want (%q, %q, %q)",
+					tt.from, tt.to, debit, credit, event, tt.wantDebit, tt.wantCredit, tt.wantEvent)
+			}
+		})
+	}
+}
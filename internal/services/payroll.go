@@ -0,0 +1,201 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"hubpay/internal/models"
+)
+
+// PayrollService groups approved commissions into payout batches and pays
+// them out atomically, so a batch can never leave some member commissions
+// paid and others not.
+type PayrollService struct {
+	db         *gorm.DB
+	commission *CommissionService
+}
+
+func NewPayrollService(db *gorm.DB, commissionService *CommissionService) *PayrollService {
+	return &PayrollService{db: db, commission: commissionService}
+}
+
+// CreatePayroll builds a draft payroll batch from either explicit
+// CommissionIDs or, when that's empty, every `approved` commission in
+// [periodStart, periodEnd] for the given AEIDs (all AEs if aeIDs is
+// empty). It refuses any commission that isn't currently `approved`.
+func (s *PayrollService) CreatePayroll(req models.CreatePayrollRequest, periodStart, periodEnd time.Time, createdBy uint) (*models.Payroll, error) {
+	var commissions []models.Commission
+	if len(req.CommissionIDs) > 0 {
+		if err := s.db.Where("id IN ?", req.CommissionIDs).Find(&commissions).Error; err != nil {
+			return nil, fmt.Errorf("load commissions: %w", err)
+		}
+	} else {
+		query := s.db.Where("status = ? AND created_at >= ? AND created_at <= ?", "approved", periodStart, periodEnd)
+		if len(req.AEIDs) > 0 {
+			query = query.Where("ae_id IN ?", req.AEIDs)
+		}
+		if err := query.Find(&commissions).Error; err != nil {
+			return nil, fmt.Errorf("load commissions: %w", err)
+		}
+	}
+
+	if len(commissions) == 0 {
+		return nil, fmt.Errorf("no approved commissions matched this payroll")
+	}
+
+	total, err := sumApprovedReportingAmount(commissions)
+	if err != nil {
+		return nil, err
+	}
+
+	payroll := &models.Payroll{
+		Title:       req.Title,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Status:      "draft",
+		TotalAmount: total,
+		CreatedBy:   createdBy,
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(payroll).Error; err != nil {
+			return err
+		}
+		for _, commission := range commissions {
+			if err := tx.Create(&models.PayrollCommission{PayrollID: payroll.ID, CommissionID: commission.ID}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create payroll: %w", err)
+	}
+
+	return payroll, nil
+}
+
+// Approve moves a draft payroll to approved, recording who signed off.
+func (s *PayrollService) Approve(payrollID, actorID uint) (*models.Payroll, error) {
+	var payroll models.Payroll
+	if err := s.db.First(&payroll, payrollID).Error; err != nil {
+		return nil, fmt.Errorf("payroll not found: %w", err)
+	}
+	if payroll.Status != "draft" {
+		return nil, fmt.Errorf("payroll %d is %q, not draft", payrollID, payroll.Status)
+	}
+
+	if err := s.db.Model(&payroll).Updates(map[string]interface{}{
+		"status":      "approved",
+		"approved_by": actorID,
+	}).Error; err != nil {
+		return nil, fmt.Errorf("approve payroll: %w", err)
+	}
+
+	payroll.Status = "approved"
+	payroll.ApprovedBy = &actorID
+	return &payroll, nil
+}
+
+// Pay transitions an approved payroll to paid, flipping every member
+// commission to paid (stamping ApprovedBy/ApprovedAt on each via the same
+// transition CommissionHandler uses) inside a single transaction, so a
+// partial payout can't happen.
+func (s *PayrollService) Pay(payrollID, actorID uint) (*models.Payroll, error) {
+	var payroll models.Payroll
+	if err := s.db.First(&payroll, payrollID).Error; err != nil {
+		return nil, fmt.Errorf("payroll not found: %w", err)
+	}
+	if payroll.Status != "approved" {
+		return nil, fmt.Errorf("payroll %d is %q, not approved", payrollID, payroll.Status)
+	}
+
+	var members []models.PayrollCommission
+	if err := s.db.Where("payroll_id = ?", payrollID).Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("load payroll members: %w", err)
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		for _, member := range members {
+			if _, err := s.commission.UpdateStatusTx(tx, member.CommissionID, "paid", actorID, ""); err != nil {
+				return fmt.Errorf("commission %d: %w", member.CommissionID, err)
+			}
+		}
+
+		now := time.Now()
+		return tx.Model(&payroll).Updates(map[string]interface{}{
+			"status":  "paid",
+			"paid_at": now,
+		}).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pay payroll: %w", err)
+	}
+
+	payroll.Status = "paid"
+	now := time.Now()
+	payroll.PaidAt = &now
+	return &payroll, nil
+}
+
+// ExportCSV renders a payroll's member commissions as a flat CSV, one row
+// per commission, suitable for handing to a NACHA-file generator or a
+// bank's ACH batch upload.
+func (s *PayrollService) ExportCSV(payrollID uint) ([]byte, error) {
+	var payroll models.Payroll
+	if err := s.db.First(&payroll, payrollID).Error; err != nil {
+		return nil, fmt.Errorf("payroll not found: %w", err)
+	}
+
+	var members []models.PayrollCommission
+	if err := s.db.Preload("Commission.AE").Where("payroll_id = ?", payrollID).Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("load payroll members: %w", err)
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write([]string{"ae_id", "ae_name", "ae_email", "commission_id", "amount_cents", "currency", "status"}); err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		commission := member.Commission
+		row := []string{
+			strconv.FormatUint(uint64(commission.AEID), 10),
+			commission.AE.Name,
+			commission.AE.Email,
+			strconv.FormatUint(uint64(commission.ID), 10),
+			strconv.FormatInt(commission.TotalCommission, 10),
+			commission.Currency,
+			commission.Status,
+		}
+		if err := writer.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// sumApprovedReportingAmount totals a payroll batch's commissions in each
+// one's ReportingAmount (so a batch spanning AEs who close deals in
+// different currencies still has one meaningful total), refusing the whole
+// batch if any member isn't currently `approved`.
+func sumApprovedReportingAmount(commissions []models.Commission) (int64, error) {
+	var total int64
+	for _, commission := range commissions {
+		if commission.Status != "approved" {
+			return 0, fmt.Errorf("commission %d is %q, not approved", commission.ID, commission.Status)
+		}
+		total += commission.ReportingAmount
+	}
+	return total, nil
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"testing"
+
+	"hubpay/internal/models"
+)
+
+func TestSumApprovedReportingAmount(t *testing.T) {
+	tests := []struct {
+		name        string
+		commissions []models.Commission
+		wantTotal   int64
+		wantErr     bool
+	}{
+		{
+			name: "all approved sums reporting amount",
+			commissions: []models.Commission{
+				{ID: 1, Status: "approved", ReportingAmount: 1000},
+				{ID: 2, Status: "approved", ReportingAmount: 2500},
+			},
+			wantTotal: 3500,
+		},
+		{
+			name: "non-approved commission rejects the whole batch",
+			commissions: []models.Commission{
+				{ID: 1, Status: "approved", ReportingAmount: 1000},
+				{ID: 2, Status: "paid", ReportingAmount: 2500},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			total, err := sumApprovedReportingAmount(tt.commissions)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("sumApprovedReportingAmount() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("sumApprovedReportingAmount() error = %v", err)
+			}
+			if total != tt.wantTotal {
+				t.Fatalf("sumApprovedReportingAmount() = %d, want %d", total, tt.wantTotal)
+			}
+		})
+	}
+}
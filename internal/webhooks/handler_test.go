@@ -0,0 +1,26 @@
+package webhooks
+
+import "testing"
+
+func TestCheckRetryable(t *testing.T) {
+	tests := []struct {
+		status  string
+		wantErr bool
+	}{
+		{"pending", false},
+		{"failed", false},
+		{"succeeded", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			err := checkRetryable(1, tt.status)
+			if tt.wantErr && err == nil {
+				t.Fatalf("checkRetryable(1, %q) error = nil, want error", tt.status)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("checkRetryable(1, %q) error = %v, want nil", tt.status, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package webhooks
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxClockSkew is how far a webhook's declared send time may drift from now
+// before it's rejected as a possible replay.
+const MaxClockSkew = 5 * time.Minute
+
+// ErrStaleTimestamp is returned when a webhook's timestamp header falls
+// outside MaxClockSkew of the current time.
+var ErrStaleTimestamp = errors.New("webhooks: timestamp outside allowed clock skew")
+
+// VerifyTabsSignature checks the Tabs-Signature header: an HMAC-SHA256 of
+// the raw request body, hex-encoded, keyed by the Tabs API key.
+func VerifyTabsSignature(secret string, body []byte, signatureHeader string) bool {
+	if signatureHeader == "" {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(signatureHeader)) == 1
+}
+
+// VerifySendGridSignature checks the ECDSA signature SendGrid's Event
+// Webhook attaches to each delivery, computed over timestamp||body.
+func VerifySendGridSignature(publicKeyBase64 string, body []byte, signatureHeader, timestampHeader string) (bool, error) {
+	if signatureHeader == "" || timestampHeader == "" {
+		return false, nil
+	}
+
+	pubKey, err := parseSendGridPublicKey(publicKeyBase64)
+	if err != nil {
+		return false, err
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signatureHeader)
+	if err != nil {
+		return false, fmt.Errorf("webhooks: decode signature: %w", err)
+	}
+
+	signed := append([]byte(timestampHeader), body...)
+	hash := sha256.Sum256(signed)
+
+	return ecdsa.VerifyASN1(pubKey, hash[:], sig), nil
+}
+
+func parseSendGridPublicKey(publicKeyBase64 string) (*ecdsa.PublicKey, error) {
+	der, err := base64.StdEncoding.DecodeString(publicKeyBase64)
+	if err != nil {
+		return nil, fmt.Errorf("webhooks: decode sendgrid public key: %w", err)
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("webhooks: parse sendgrid public key: %w", err)
+	}
+
+	ecdsaKey, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("webhooks: sendgrid public key is not ECDSA")
+	}
+
+	return ecdsaKey, nil
+}
+
+// CheckTimestampFreshness rejects a webhook whose declared send time is
+// further than MaxClockSkew from now in either direction, blocking replay
+// of an intercepted but validly-signed payload.
+func CheckTimestampFreshness(unixSeconds int64) error {
+	sentAt := time.Unix(unixSeconds, 0)
+	skew := time.Since(sentAt)
+	if skew > MaxClockSkew || skew < -MaxClockSkew {
+		return ErrStaleTimestamp
+	}
+	return nil
+}